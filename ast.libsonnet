@@ -0,0 +1,166 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+
+local wrapArray(val) = if std.type(val) == 'array' then val else [val];
+
+local NodeBase = {
+  fodder(f):: self { fodder::: wrapArray(f) },
+};
+
+{
+  Apply: NodeBase {
+    __kind__: 'Apply',
+    fodderLeft(f):: self { fodderLeft::: wrapArray(f) },
+    fodderRight(f):: self { fodderRight::: wrapArray(f) },
+    tailStrictFodder(f):: self { tailStrictFodder::: wrapArray(f) },
+  },
+  ApplyBrace: NodeBase {
+    __kind__: 'ApplyBrace',
+  },
+  Array: NodeBase {
+    __kind__: 'Array',
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  ArrayComp: NodeBase {
+    __kind__: 'ArrayComp',
+    trailingCommaFodder(f):: self { trailingCommaFodder::: wrapArray(f) },
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  Assert: NodeBase {
+    __kind__: 'Assert',
+    colonFodder(f):: self { colonFodder::: wrapArray(f) },
+    semicolonFodder(f):: self { semicolonFodder::: wrapArray(f) },
+  },
+  Binary: NodeBase {
+    __kind__: 'Binary',
+    opFodder(f):: self { opFodder::: wrapArray(f) },
+  },
+  CommaSeparatedExpr: {
+    __kind__: 'CommaSeparatedExpr',
+    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+  },
+  Conditional: NodeBase {
+    __kind__: 'Conditional',
+    thenFodder(f):: self { thenFodder::: wrapArray(f) },
+    elseFodder(f):: self { elseFodder::: wrapArray(f) },
+  },
+  DesugaredObject: NodeBase {
+    __kind__: 'DesugaredObject',
+  },
+  DesugaredObjectField: {
+    __kind__: 'DesugaredObjectField',
+  },
+  Dollar: NodeBase {
+    __kind__: 'Dollar',
+  },
+  Error: NodeBase {
+    __kind__: 'Error',
+  },
+  ForSpec: {
+    __kind__: 'ForSpec',
+    forFodder(f):: self { forFodder::: wrapArray(f) },
+    varFodder(f):: self { varFodder::: wrapArray(f) },
+    inFodder(f):: self { inFodder::: wrapArray(f) },
+  },
+  Function: NodeBase {
+    __kind__: 'Function',
+    parenLeftFodder(f):: self { parenLeftFodder::: wrapArray(f) },
+    parenRightFodder(f):: self { parenRightFodder::: wrapArray(f) },
+  },
+  IfSpec: {
+    __kind__: 'IfSpec',
+    ifFodder(f):: self { ifFodder::: wrapArray(f) },
+  },
+  Import: NodeBase {
+    __kind__: 'Import',
+  },
+  ImportBin: NodeBase {
+    __kind__: 'ImportBin',
+  },
+  ImportStr: NodeBase {
+    __kind__: 'ImportStr',
+  },
+  InSuper: NodeBase {
+    __kind__: 'InSuper',
+    inFodder(f):: self { inFodder::: wrapArray(f) },
+    superFodder(f):: self { superFodder::: wrapArray(f) },
+  },
+  Index: NodeBase {
+    __kind__: 'Index',
+    rightBracketFodder(f):: self { rightBracketFodder::: wrapArray(f) },
+    leftBracketFodder(f):: self { leftBracketFodder::: wrapArray(f) },
+  },
+  LiteralBoolean: NodeBase {
+    __kind__: 'LiteralBoolean',
+  },
+  LiteralNull: NodeBase {
+    __kind__: 'LiteralNull',
+  },
+  LiteralNumber: NodeBase {
+    __kind__: 'LiteralNumber',
+  },
+  LiteralString: NodeBase {
+    __kind__: 'LiteralString',
+  },
+  Local: NodeBase {
+    __kind__: 'Local',
+  },
+  LocalBind: {
+    __kind__: 'LocalBind',
+    varFodder(f):: self { varFodder::: wrapArray(f) },
+    eqFodder(f):: self { eqFodder::: wrapArray(f) },
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  NamedArgument: {
+    __kind__: 'NamedArgument',
+    nameFodder(f):: self { nameFodder::: wrapArray(f) },
+    eqFodder(f):: self { eqFodder::: wrapArray(f) },
+    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+  },
+  Object: NodeBase {
+    __kind__: 'Object',
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  ObjectComp: NodeBase {
+    __kind__: 'ObjectComp',
+    trailingCommaFodder(f):: self { trailingCommaFodder::: wrapArray(f) },
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  ObjectField: {
+    __kind__: 'ObjectField',
+    fodder2(f):: self { fodder2::: wrapArray(f) },
+    fodder1(f):: self { fodder1::: wrapArray(f) },
+    opFodder(f):: self { opFodder::: wrapArray(f) },
+    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+  },
+  Parameter: {
+    __kind__: 'Parameter',
+    nameFodder(f):: self { nameFodder::: wrapArray(f) },
+    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+    eqFodder(f):: self { eqFodder::: wrapArray(f) },
+  },
+  Parens: NodeBase {
+    __kind__: 'Parens',
+    closeFodder(f):: self { closeFodder::: wrapArray(f) },
+  },
+  Self: NodeBase {
+    __kind__: 'Self',
+  },
+  Slice: NodeBase {
+    __kind__: 'Slice',
+    leftBracketFodder(f):: self { leftBracketFodder::: wrapArray(f) },
+    endColonFodder(f):: self { endColonFodder::: wrapArray(f) },
+    stepColonFodder(f):: self { stepColonFodder::: wrapArray(f) },
+    rightBracketFodder(f):: self { rightBracketFodder::: wrapArray(f) },
+  },
+  SuperIndex: NodeBase {
+    __kind__: 'SuperIndex',
+    idFodder(f):: self { idFodder::: wrapArray(f) },
+    dotFodder(f):: self { dotFodder::: wrapArray(f) },
+  },
+  Unary: NodeBase {
+    __kind__: 'Unary',
+  },
+  Var: NodeBase {
+    __kind__: 'Var',
+  },
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/marcbran/jsonnet-plugin-jsonnet/jsonnet"
+)
+
+const stdinFilename = "<stdin>"
+
+var errUsage = errors.New("usage")
+
+// errFailed signals a non-zero exit after the command already reported why.
+var errFailed = errors.New("failed")
+
+type command struct {
+	usage string
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"parse": {
+		usage: "parse [-compact] [file]",
+		run:   (*cli).parse,
+	},
+	"manifest": {
+		usage: "manifest [-options json] [file]",
+		run:   (*cli).manifest,
+	},
+	"format": {
+		usage: "format [-options json] [-check | -write] [-workers n] [path ...]",
+		run:   (*cli).format,
+	},
+	"lint": {
+		usage: "lint [-config json] [file]",
+		run:   (*cli).lint,
+	},
+	"query": {
+		usage: "query selector [file]",
+		run:   (*cli).query,
+	},
+}
+
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func Run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		c.usage()
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command: %s\n", args[0])
+		c.usage()
+		return 2
+	}
+	err := cmd.run(c, args[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "usage: %s\n", cmd.usage)
+		return 2
+	case errors.Is(err, errFailed):
+		return 1
+	default:
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+}
+
+func (c *cli) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(c.stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+func (c *cli) flags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {}
+	return flags
+}
+
+func (c *cli) parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+	return nil
+}
+
+func (c *cli) readInput(args []string) (string, string, error) {
+	if len(args) > 1 {
+		return "", "", errUsage
+	}
+	if len(args) == 0 || args[0] == "-" {
+		b, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return "", "", err
+		}
+		return stdinFilename, string(b), nil
+	}
+	b, err := os.ReadFile(args[0])
+	if err != nil {
+		return "", "", err
+	}
+	return args[0], string(b), nil
+}
+
+func (c *cli) writeJSON(val any) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(val)
+}
+
+func decodeJSONFlag(name string, val string) (any, error) {
+	if val == "" {
+		return nil, nil
+	}
+	var res any
+	err := json.Unmarshal([]byte(val), &res)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return res, nil
+}
+
+func formatOptions(val string) (formatter.Options, error) {
+	rawOptions, err := decodeJSONFlag("options", val)
+	if err != nil {
+		return formatter.Options{}, err
+	}
+	return jsonnet.NewFormatOptions(rawOptions)
+}
+
+func (c *cli) parse(args []string) error {
+	flags := c.flags("parse")
+	compact := flags.Bool("compact", false, "omit empty fodder and context from the AST")
+	err := c.parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	filename, code, err := c.readInput(flags.Args())
+	if err != nil {
+		return err
+	}
+	node, err := jsonnet.Parse(filename, code, jsonnet.ParseOptions{Compact: *compact})
+	if err != nil {
+		return err
+	}
+	return c.writeJSON(node)
+}
+
+func (c *cli) manifest(args []string) error {
+	flags := c.flags("manifest")
+	rawOptions := flags.String("options", "", "formatter options as a JSON object")
+	err := c.parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	options, err := formatOptions(*rawOptions)
+	if err != nil {
+		return err
+	}
+	_, input, err := c.readInput(flags.Args())
+	if err != nil {
+		return err
+	}
+	var node any
+	err = json.Unmarshal([]byte(input), &node)
+	if err != nil {
+		return fmt.Errorf("invalid AST: %w", err)
+	}
+	code, err := jsonnet.Manifest(node, options)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(c.stdout, code)
+	return err
+}
+
+func (c *cli) lint(args []string) error {
+	flags := c.flags("lint")
+	rawConfig := flags.String("config", "", "lint rule configuration as a JSON object")
+	err := c.parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	configValue, err := decodeJSONFlag("config", *rawConfig)
+	if err != nil {
+		return err
+	}
+	config, err := jsonnet.NewLintConfig(configValue)
+	if err != nil {
+		return err
+	}
+	filename, code, err := c.readInput(flags.Args())
+	if err != nil {
+		return err
+	}
+	diagnostics, err := jsonnet.Lint(filename, code, config)
+	if err != nil {
+		return err
+	}
+	err = c.writeJSON(diagnostics)
+	if err != nil {
+		return err
+	}
+	for _, elem := range diagnostics.([]any) {
+		if elem.(map[string]any)["severity"] == string(jsonnet.SeverityError) {
+			return errFailed
+		}
+	}
+	return nil
+}
+
+func (c *cli) query(args []string) error {
+	flags := c.flags("query")
+	err := c.parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
+		return errUsage
+	}
+	selector := flags.Arg(0)
+	_, input, err := c.readInput(flags.Args()[1:])
+	if err != nil {
+		return err
+	}
+	var root any = input
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		var node map[string]any
+		if json.Unmarshal([]byte(input), &node) == nil && node["__kind__"] != nil {
+			root = node
+		}
+	}
+	matches, err := jsonnet.Query(root, selector)
+	if err != nil {
+		return err
+	}
+	return c.writeJSON(matches)
+}
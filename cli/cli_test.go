@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.jsonnet")
+	require.NoError(t, os.WriteFile(file, []byte("{a:1}"), 0o644))
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		code     int
+		contains []string
+	}{
+		{
+			name:     "parse stdin",
+			args:     []string{"parse", "-compact"},
+			stdin:    "a",
+			contains: []string{`"__kind__": "Var"`, `"fileName": "<stdin>"`},
+		},
+		{
+			name:     "parse file",
+			args:     []string{"parse", file},
+			contains: []string{`"__kind__": "Object"`, `"fileName": "` + file + `"`},
+		},
+		{
+			name:     "manifest",
+			args:     []string{"manifest"},
+			stdin:    `{"__kind__": "Var", "id": "a"}`,
+			contains: []string{"a\n"},
+		},
+		{
+			name:     "manifest options",
+			args:     []string{"manifest", "-options", `{"stringStyle": "double"}`},
+			stdin:    `{"__kind__": "LiteralString", "value": "a", "kind": 1}`,
+			contains: []string{`"a"`},
+		},
+		{
+			name:     "format",
+			args:     []string{"format", file},
+			contains: []string{"{ a: 1 }\n"},
+		},
+		{
+			name:     "format options",
+			args:     []string{"format", "-options", `{"indent": 4}`, "-"},
+			stdin:    "{\na: 1}",
+			contains: []string{"{\n    a: 1,\n}\n"},
+		},
+		{
+			name:     "lint warnings",
+			args:     []string{"lint"},
+			stdin:    "std.extVar('a')\n",
+			contains: []string{`"rule": "ext-var"`},
+		},
+		{
+			name:     "lint clean",
+			args:     []string{"lint"},
+			stdin:    "{ a: 1 }\n",
+			contains: []string{"[]\n"},
+		},
+		{
+			name:     "lint errors",
+			args:     []string{"lint", "-config", `{"ext-var": false}`},
+			stdin:    "{ a: 1, ['a']: 2 }\n",
+			code:     1,
+			contains: []string{`"rule": "quoted-field-duplicate"`},
+		},
+		{
+			name:     "query code",
+			args:     []string{"query", "Var[id=b]"},
+			stdin:    "[a, b]",
+			contains: []string{`"path": "$.elements[1].expr"`},
+		},
+		{
+			name:     "query no match",
+			args:     []string{"query", "Error"},
+			stdin:    "[a, b]",
+			contains: []string{"[]\n"},
+		},
+		{
+			name:     "query ast",
+			args:     []string{"query", "Var"},
+			stdin:    `{"__kind__": "Var", "id": "a"}`,
+			contains: []string{`"path": "$"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equal(t, tt.code, code, stderr.String())
+			for _, expected := range tt.contains {
+				assert.Contains(t, stdout.String(), expected)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		code     int
+		expected string
+	}{
+		{
+			name:     "no command",
+			args:     []string{},
+			code:     2,
+			expected: "usage:",
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"foo"},
+			code:     2,
+			expected: "unknown command: foo",
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"parse", "-foo"},
+			code:     2,
+			expected: "usage: parse [-compact] [file]",
+		},
+		{
+			name:     "too many files",
+			args:     []string{"format", "a.jsonnet", "b.jsonnet"},
+			code:     2,
+			expected: "usage: format",
+		},
+		{
+			name:     "missing selector",
+			args:     []string{"query"},
+			code:     2,
+			expected: "usage: query selector [file]",
+		},
+		{
+			name:     "missing file",
+			args:     []string{"parse", "missing.jsonnet"},
+			code:     1,
+			expected: "error: open missing.jsonnet",
+		},
+		{
+			name:     "parse error",
+			args:     []string{"parse"},
+			stdin:    "{",
+			code:     1,
+			expected: "error: <stdin>:1:2",
+		},
+		{
+			name:     "invalid ast",
+			args:     []string{"manifest"},
+			stdin:    "{",
+			code:     1,
+			expected: "error: invalid AST",
+		},
+		{
+			name:     "invalid options",
+			args:     []string{"format", "-options", "{"},
+			code:     1,
+			expected: "error: invalid options",
+		},
+		{
+			name:     "unknown lint rule",
+			args:     []string{"lint", "-config", `{"foo": true}`},
+			code:     1,
+			expected: "error: unknown lint rule: foo",
+		},
+		{
+			name:     "invalid selector",
+			args:     []string{"query", "["},
+			stdin:    "a",
+			code:     1,
+			expected: "error: invalid selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := Run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, stderr.String(), tt.expected)
+		})
+	}
+}
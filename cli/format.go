@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+func (c *cli) format(args []string) error {
+	flags := c.flags("format")
+	rawOptions := flags.String("options", "", "formatter options as a JSON object")
+	check := flags.Bool("check", false, "print a diff for every file whose formatting would change")
+	write := flags.Bool("write", false, "rewrite files whose formatting would change")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of files formatted in parallel")
+	err := c.parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if *check && *write || *workers < 1 {
+		return errUsage
+	}
+	options, err := formatOptions(*rawOptions)
+	if err != nil {
+		return err
+	}
+	if !*check && !*write {
+		filename, code, err := c.readInput(flags.Args())
+		if err != nil {
+			return err
+		}
+		formatted, err := formatter.Format(filename, code, options)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(c.stdout, formatted)
+		return err
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := findJsonnetFiles(paths)
+	if err != nil {
+		return err
+	}
+	failed := false
+	for _, res := range formatFiles(files, options, *workers) {
+		switch {
+		case res.err != nil:
+			fmt.Fprintf(c.stderr, "error: %v\n", res.err)
+			failed = true
+		case res.formatted == res.original:
+		case *check:
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(res.original),
+				B:        difflib.SplitLines(res.formatted),
+				FromFile: res.path,
+				ToFile:   res.path,
+				Context:  3,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(c.stdout, diff)
+			if err != nil {
+				return err
+			}
+			failed = true
+		case *write:
+			err = writeFile(res.path, res.formatted)
+			if err != nil {
+				fmt.Fprintf(c.stderr, "error: %v\n", err)
+				failed = true
+				continue
+			}
+			fmt.Fprintln(c.stdout, res.path)
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+func findJsonnetFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if ext := filepath.Ext(path); ext == ".jsonnet" || ext == ".libsonnet" {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+type formatResult struct {
+	path      string
+	original  string
+	formatted string
+	err       error
+}
+
+func formatFiles(files []string, options formatter.Options, workers int) []formatResult {
+	results := make([]formatResult, len(files))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = formatFile(files[i], options)
+			}
+		}()
+	}
+	for i := range files {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
+
+func formatFile(path string, options formatter.Options) formatResult {
+	res := formatResult{path: path}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	res.original = string(b)
+	res.formatted, res.err = formatter.Format(path, res.original, options)
+	return res
+}
+
+func writeFile(path string, content string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), info.Mode().Perm())
+}
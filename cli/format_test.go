@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func readTree(t *testing.T, dir string, names ...string) map[string]string {
+	res := make(map[string]string)
+	for _, name := range names {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		res[name] = string(b)
+	}
+	return res
+}
+
+func TestFormatTree(t *testing.T) {
+	files := map[string]string{
+		"main.jsonnet":            "{a:1}",
+		"lib/formatted.libsonnet": "{ b: 2 }\n",
+		"lib/nested/c.libsonnet":  "[1,2]",
+		"lib/nested/d.jsonnet":    "local x = 1; x",
+		"lib/readme.md":           "{a:1}",
+		".hidden/skipped.jsonnet": "{a:1}",
+	}
+	changed := []string{
+		"lib/nested/c.libsonnet",
+		"lib/nested/d.jsonnet",
+		"main.jsonnet",
+	}
+
+	t.Run("check", func(t *testing.T) {
+		dir := writeTree(t, files)
+		var stdout, stderr bytes.Buffer
+		code := Run([]string{"format", "-check", "-workers", "4", dir}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 1, code, stderr.String())
+
+		var diffed []string
+		for _, line := range strings.Split(stdout.String(), "\n") {
+			if name, ok := strings.CutPrefix(line, "--- "+dir+string(filepath.Separator)); ok {
+				diffed = append(diffed, filepath.ToSlash(strings.TrimSpace(name)))
+			}
+		}
+		assert.Equal(t, changed, diffed)
+		assert.Contains(t, stdout.String(), "-{a:1}\n+{ a: 1 }\n")
+		assert.Equal(t, "{a:1}", readTree(t, dir, "main.jsonnet")["main.jsonnet"])
+	})
+
+	t.Run("write", func(t *testing.T) {
+		dir := writeTree(t, files)
+		var stdout, stderr bytes.Buffer
+		code := Run([]string{"format", "-write", dir}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 0, code, stderr.String())
+
+		var written []string
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			written = append(written, filepath.ToSlash(strings.TrimPrefix(line, dir+string(filepath.Separator))))
+		}
+		assert.Equal(t, changed, written)
+		assert.Equal(t, map[string]string{
+			"main.jsonnet":            "{ a: 1 }\n",
+			"lib/nested/c.libsonnet":  "[1, 2]\n",
+			"lib/readme.md":           "{a:1}",
+			".hidden/skipped.jsonnet": "{a:1}",
+		}, readTree(t, dir, "main.jsonnet", "lib/nested/c.libsonnet", "lib/readme.md", ".hidden/skipped.jsonnet"))
+
+		stdout.Reset()
+		code = Run([]string{"format", "-check", dir}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 0, code)
+		assert.Empty(t, stdout.String())
+	})
+}
+
+func TestFormatTreeFiles(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.txt":     "{a:1}",
+		"b.jsonnet": "{b:2}",
+	})
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"format", "-check", filepath.Join(dir, "a.txt")}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout.String(), "+{ a: 1 }")
+	assert.NotContains(t, stdout.String(), "b.jsonnet")
+}
+
+func TestFormatTreeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     func(dir string) []string
+		code     int
+		expected string
+	}{
+		{
+			name:     "check and write",
+			args:     func(dir string) []string { return []string{"format", "-check", "-write", dir} },
+			code:     2,
+			expected: "usage: format",
+		},
+		{
+			name:     "no workers",
+			args:     func(dir string) []string { return []string{"format", "-check", "-workers", "0", dir} },
+			code:     2,
+			expected: "usage: format",
+		},
+		{
+			name:     "missing path",
+			args:     func(dir string) []string { return []string{"format", "-check", filepath.Join(dir, "missing")} },
+			code:     1,
+			expected: "no such file or directory",
+		},
+		{
+			name:     "parse error",
+			args:     func(dir string) []string { return []string{"format", "-write", dir} },
+			code:     1,
+			expected: "broken.jsonnet:1:2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTree(t, map[string]string{
+				"broken.jsonnet": "{",
+				"ok.jsonnet":     "{a:1}",
+			})
+			var stdout, stderr bytes.Buffer
+			code := Run(tt.args(dir), strings.NewReader(""), &stdout, &stderr)
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, stderr.String(), tt.expected)
+		})
+	}
+}
@@ -59,6 +59,28 @@ p.ex({}, {
       ),
     expected: "'foo %s %s' % ['bar', 'baz']",
   }]),
+  TextBlock: p.ex([{
+    name: 'simple',
+    example:
+      j.manifestJsonnet(
+        j.TextBlock('foo\nbar\n'),
+      ),
+    expected: '|||\n  foo\n  bar\n|||',
+  }, {
+    name: 'chomped',
+    example:
+      j.manifestJsonnet(
+        j.TextBlock('foo'),
+      ),
+    expected: '|||-\n  foo\n|||',
+  }]),
+  VerbatimString: p.ex({
+    example:
+      j.manifestJsonnet(
+        j.VerbatimString("foo\\bar 'baz'"),
+      ),
+    expected: "@'foo\\bar ''baz'''",
+  }),
   Number: p.ex({
     example:
       j.manifestJsonnet(
@@ -202,6 +224,41 @@ p.ex({}, {
         j.Object([j.FieldFunction('a', [], j.Number('1'))]),
       ),
     expected: '{ a(): 1 }',
+  }, {
+    name: 'hidden field',
+    example:
+      j.manifestJsonnet(
+        j.Object([j.HiddenField('a', j.Number('1'))]),
+      ),
+    expected: '{ a:: 1 }',
+  }, {
+    name: 'forced field',
+    example:
+      j.manifestJsonnet(
+        j.Object([j.ForcedField('a', j.Number('1'))]),
+      ),
+    expected: '{ a::: 1 }',
+  }, {
+    name: 'plus field',
+    example:
+      j.manifestJsonnet(
+        j.Object([j.Field('a', j.Number('1')).plus(), j.HiddenField(j.Var('b'), j.Number('2')).plus()]),
+      ),
+    expected: '{ a+: 1, [b]+:: 2 }',
+  }, {
+    name: 'hidden field func',
+    example:
+      j.manifestJsonnet(
+        j.Object([j.HiddenFieldFunction('a', [], j.Number('1'))]),
+      ),
+    expected: '{ a():: 1 }',
+  }, {
+    name: 'forced field func',
+    example:
+      j.manifestJsonnet(
+        j.Object([j.ForcedFieldFunction('a', [], j.Number('1'))]),
+      ),
+    expected: '{ a()::: 1 }',
   }, {
     name: 'local',
     example:
@@ -539,6 +596,113 @@ p.ex({}, {
       ),
     expected: '!a',
   }]),
+  Capture: p.ex([{
+    name: 'transform',
+    example:
+      j.transformJsonnet("{ name: std.extVar('name') }", [{
+        pattern: j.Std.extVar(j.Capture('x')),
+        replacement: j.Member(j.Var('config'), j.Capture('x')),
+      }]),
+    expected: '{ name: config.name }',
+  }]),
+  equalJsonnet: p.ex([{
+    name: 'formatting and quoting',
+    example: j.equalJsonnet("{a:1,'b':[2]}", '{ a: 1, b: [2] }'),
+    expected: true,
+  }, {
+    name: 'ast and code',
+    example: j.equalJsonnet(j.Member(j.Var('a'), 'b'), "a['b']"),
+    expected: true,
+  }, {
+    name: 'field order',
+    example: j.equalJsonnet('{ a: 1, b: 2 }', '{ b: 2, a: 1 }'),
+    expected: false,
+  }, {
+    name: 'ignore field order',
+    example: j.equalJsonnet('{ a: 1, b: 2 }', '{ b: 2, a: 1 }', { ignoreFieldOrder: true }),
+    expected: true,
+  }, {
+    name: 'ignore parens',
+    example: j.equalJsonnet(j.Add(j.Var('a'), j.Number('1')), '(a + 1)', { ignoreParens: true }),
+    expected: true,
+  }]),
+  fromValue: p.ex([{
+    name: 'object',
+    example:
+      j.manifestJsonnet(
+        j.fromValue({ name: 'foo', 'api-version': 1, tags: ['a', 'b'], enabled: true, parent: null }),
+      ),
+    expected: "{ 'api-version': 1, enabled: true, name: 'foo', parent: null, tags: ['a', 'b'] }",
+  }, {
+    name: 'field order',
+    example:
+      j.manifestJsonnet(
+        j.fromValue({ b: 1, a: 2, c: 3 }, { fieldOrder: ['c', 'b'] }),
+      ),
+    expected: '{ c: 3, b: 1, a: 2 }',
+  }, {
+    name: 'multiline',
+    example:
+      j.manifestJsonnet(
+        j.fromValue({ a: [1, -2] }, { multiline: true }),
+      ),
+    expected: |||-
+      {
+        a: [
+          1,
+          -2,
+        ],
+      }
+    |||,
+  }]),
+  template: p.ex([{
+    name: 'ast hole',
+    example:
+      j.manifestJsonnet(
+        j.template('local x = $$v; x + 1', { v: j.Number('2') }),
+      ),
+    expected: 'local x = 2; x + 1',
+  }, {
+    name: 'value hole',
+    example:
+      j.manifestJsonnet(
+        j.template('{ name: $$name, labels: $$labels }', { name: 'web', labels: { app: 'web' } }),
+      ),
+    expected: "{ name: 'web', labels: { app: 'web' } }",
+  }]),
+  jsonToJsonnet: p.ex([{
+    name: 'object',
+    example: j.jsonToJsonnet('{"name": "web", "ports": [80, 443]}'),
+    expected: |||-
+      {
+        name: 'web',
+        ports: [
+          80,
+          443,
+        ],
+      }
+    |||,
+  }]),
+  yamlToJsonnet: p.ex([{
+    name: 'comments',
+    example: j.yamlToJsonnet(|||
+      # web server
+      name: web
+      ports:
+        - 80 # http
+        - 443
+    |||),
+    expected: |||-
+      {
+        // web server
+        name: 'web',
+        ports: [
+          80,  // http
+          443,
+        ],
+      }
+    |||,
+  }]),
   Std: p.ex([{
     name: 'get',
     example:
@@ -0,0 +1,553 @@
+// Command gen generates the JSON proxies in jsonnet/ast_gen.go and the DSL
+// node objects in ast.libsonnet from the structs of go-jsonnet's ast package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+// nodes are the kinds of ast.Node that can be marshalled.
+var nodes = []any{
+	ast.Apply{},
+	ast.ApplyBrace{},
+	ast.Array{},
+	ast.ArrayComp{},
+	ast.Assert{},
+	ast.Binary{},
+	ast.Conditional{},
+	ast.DesugaredObject{},
+	ast.Dollar{},
+	ast.Error{},
+	ast.Function{},
+	ast.Import{},
+	ast.ImportBin{},
+	ast.ImportStr{},
+	ast.InSuper{},
+	ast.Index{},
+	ast.LiteralBoolean{},
+	ast.LiteralNull{},
+	ast.LiteralNumber{},
+	ast.LiteralString{},
+	ast.Local{},
+	ast.Object{},
+	ast.ObjectComp{},
+	ast.Parens{},
+	ast.Self{},
+	ast.Slice{},
+	ast.SuperIndex{},
+	ast.Unary{},
+	ast.Var{},
+}
+
+// parts are the structs nested inside nodes.
+var parts = []any{
+	ast.Arguments{},
+	ast.CommaSeparatedExpr{},
+	ast.DesugaredObjectField{},
+	ast.ForSpec{},
+	ast.IfSpec{},
+	ast.LocalBind{},
+	ast.NamedArgument{},
+	ast.ObjectField{},
+	ast.Parameter{},
+}
+
+// enums are marshalled by name through the types in jsonnet/enums.go.
+var enums = []any{
+	ast.BinaryOp(0),
+	ast.LiteralStringKind(0),
+	ast.ObjectFieldHide(0),
+	ast.ObjectFieldKind(0),
+	ast.UnaryOp(0),
+}
+
+// untagged are the structs marshalled without a __kind__.
+var untagged = map[string]bool{
+	"Arguments": true,
+}
+
+// legacyKeys are the keys that older versions used for a field, by struct
+// and field name. They are still accepted when unmarshalling.
+var legacyKeys = map[string]map[string]string{
+	"ObjectField": {
+		"Hide":       "Hide",
+		"SuperSugar": "SuperSugar",
+	},
+}
+
+var (
+	nodeType          = reflect.TypeOf((*ast.Node)(nil)).Elem()
+	nodeBaseType      = reflect.TypeOf(ast.NodeBase{})
+	fodderType        = reflect.TypeOf(ast.Fodder{})
+	locationRangeType = reflect.TypeOf(ast.LocationRange{})
+	astPkgPath        = nodeBaseType.PkgPath()
+)
+
+func main() {
+	goOut := flag.String("go", "jsonnet/ast_gen.go", "output path of the Go proxies")
+	libsonnetOut := flag.String("libsonnet", "ast.libsonnet", "output path of the DSL nodes")
+	flag.Parse()
+	err := run(*goOut, *libsonnetOut)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(goOut string, libsonnetOut string) error {
+	src, err := GenerateGo()
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(goOut, src, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(libsonnetOut, GenerateLibsonnet(), 0o644)
+}
+
+type structType struct {
+	t    reflect.Type
+	node bool
+}
+
+func (s structType) name() string {
+	return s.t.Name()
+}
+
+func (s structType) tagged() bool {
+	return !untagged[s.name()]
+}
+
+func (s structType) kindField() string {
+	if _, ok := s.t.FieldByName("Kind"); ok {
+		return "NodeKind"
+	}
+	return "Kind"
+}
+
+func (s structType) receiver() string {
+	return strings.ToLower(s.name()[:1])
+}
+
+func structTypes() []structType {
+	var res []structType
+	for _, v := range nodes {
+		res = append(res, structType{t: reflect.TypeOf(v), node: true})
+	}
+	for _, v := range parts {
+		res = append(res, structType{t: reflect.TypeOf(v)})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name() < res[j].name()
+	})
+	return res
+}
+
+var (
+	generated = map[reflect.Type]bool{}
+	enumTypes = map[reflect.Type]bool{}
+)
+
+func init() {
+	for _, v := range append(append([]any{}, nodes...), parts...) {
+		generated[reflect.TypeOf(v)] = true
+	}
+	for _, v := range enums {
+		enumTypes[reflect.TypeOf(v)] = true
+	}
+}
+
+type fieldKind int
+
+const (
+	fieldPlain fieldKind = iota
+	fieldNodeBase
+	fieldNode
+	fieldNodes
+	fieldFodder
+	fieldLocationRange
+	fieldEnum
+	fieldStruct
+	fieldStructPointer
+	fieldStructs
+)
+
+func kindOf(f reflect.StructField) fieldKind {
+	t := f.Type
+	switch {
+	case f.Anonymous && t == nodeBaseType:
+		return fieldNodeBase
+	case t == nodeType:
+		return fieldNode
+	case t.Kind() == reflect.Slice && t.Elem() == nodeType:
+		return fieldNodes
+	case t == fodderType:
+		return fieldFodder
+	case t == locationRangeType:
+		return fieldLocationRange
+	case enumTypes[t]:
+		return fieldEnum
+	case generated[t]:
+		return fieldStruct
+	case t.Kind() == reflect.Pointer && generated[t.Elem()]:
+		return fieldStructPointer
+	case t.Kind() == reflect.Slice && generated[t.Elem()]:
+		return fieldStructs
+	default:
+		return fieldPlain
+	}
+}
+
+func typeString(t reflect.Type) string {
+	switch {
+	case t.Name() != "" && t.PkgPath() == astPkgPath:
+		return "ast." + t.Name()
+	case t.Name() != "":
+		return t.Name()
+	case t.Kind() == reflect.Pointer:
+		return "*" + typeString(t.Elem())
+	case t.Kind() == reflect.Slice:
+		return "[]" + typeString(t.Elem())
+	default:
+		panic(fmt.Sprintf("unsupported type %s", t))
+	}
+}
+
+func proxyTypeString(f reflect.StructField) string {
+	switch kindOf(f) {
+	case fieldNode, fieldStruct, fieldStructPointer:
+		return "json.RawMessage"
+	case fieldNodes, fieldStructs:
+		return "[]json.RawMessage"
+	case fieldFodder:
+		return "Fodder"
+	case fieldLocationRange:
+		return "LocationRange"
+	case fieldEnum:
+		return f.Type.Name()
+	default:
+		return typeString(f.Type)
+	}
+}
+
+// jsonName converts a Go field name to lower camel case, keeping initialisms
+// like ID together.
+func jsonName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+func fields(s structType) []reflect.StructField {
+	var res []reflect.StructField
+	for i := 0; i < s.t.NumField(); i++ {
+		f := s.t.Field(i)
+		if f.IsExported() {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) line(format string, args ...any) {
+	fmt.Fprintf(&w.Buffer, format, args...)
+	w.WriteByte('\n')
+}
+
+func GenerateGo() ([]byte, error) {
+	w := &writer{}
+	w.line("// Code generated by go run ./internal/gen; DO NOT EDIT.")
+	w.line("")
+	w.line("package jsonnet")
+	w.line("")
+	w.line("import (")
+	w.line("\t\"encoding/json\"")
+	w.line("\t\"fmt\"")
+	w.line("")
+	w.line("\t\"github.com/google/go-jsonnet/ast\"")
+	w.line(")")
+	types := structTypes()
+	writeNodeMarshal(w, types)
+	writeNodeUnmarshal(w, types)
+	for _, s := range types {
+		writeProxy(w, s)
+	}
+	return format.Source(w.Bytes())
+}
+
+func writeNodeMarshal(w *writer, types []structType) {
+	w.line("")
+	w.line("func (n Node) MarshalJSON() ([]byte, error) {")
+	w.line("if n.Node == nil {")
+	w.line("return []byte(\"null\"), nil")
+	w.line("}")
+	w.line("var proxy any")
+	w.line("switch v := n.Node.(type) {")
+	for _, s := range types {
+		if !s.node {
+			continue
+		}
+		w.line("case *ast.%s:", s.name())
+		w.line("proxy = %s(*v)", s.name())
+	}
+	w.line("default:")
+	w.line("return nil, &UnsupportedNodeError{Kind: fmt.Sprintf(\"%%T\", v)}")
+	w.line("}")
+	w.line("b, err := json.Marshal(proxy)")
+	w.line("if err != nil {")
+	w.line("return nil, err")
+	w.line("}")
+	w.line("return b, nil")
+	w.line("}")
+}
+
+func writeNodeUnmarshal(w *writer, types []structType) {
+	w.line("")
+	w.line("func (n *Node) UnmarshalJSON(data []byte) error {")
+	w.line("k := struct {")
+	w.line("Kind string `json:\"__kind__\"`")
+	w.line("}{}")
+	w.line("err := json.Unmarshal(data, &k)")
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	w.line("if string(data) == \"null\" {")
+	w.line("return nil")
+	w.line("}")
+	w.line("switch k.Kind {")
+	for _, s := range types {
+		if !s.node {
+			continue
+		}
+		w.line("case %q:", s.name())
+		w.line("var v %s", s.name())
+		w.line("err = json.Unmarshal(data, &v)")
+		w.line("if err != nil {")
+		w.line("return err")
+		w.line("}")
+		w.line("node := ast.%s(v)", s.name())
+		w.line("n.Node = &node")
+	}
+	w.line("default:")
+	w.line("return &UnsupportedNodeError{Kind: k.Kind}")
+	w.line("}")
+	w.line("return nil")
+	w.line("}")
+}
+
+func writeProxy(w *writer, s structType) {
+	name := s.name()
+	recv := s.receiver()
+	index := "i"
+	if recv == index {
+		index = "j"
+	}
+	legacy := legacyKeys[name]
+	w.line("")
+	w.line("type %s ast.%s", name, name)
+	w.line("")
+	w.line("type Proxy%s struct {", name)
+	if s.tagged() {
+		w.line("%s string `json:\"__kind__\"`", s.kindField())
+	}
+	for _, f := range fields(s) {
+		switch {
+		case kindOf(f) == fieldNodeBase:
+			w.line("ProxyNodeBase")
+		default:
+			w.line("%s %s `json:%q`", f.Name, proxyTypeString(f), jsonName(f.Name))
+		}
+	}
+	for _, f := range fields(s) {
+		if key, ok := legacy[f.Name]; ok {
+			w.line("Legacy%s *%s `json:%q`", f.Name, proxyTypeString(f), key+",omitempty")
+		}
+	}
+	w.line("}")
+
+	w.line("")
+	w.line("func (%s %s) MarshalJSON() ([]byte, error) {", recv, name)
+	if hasChildren(s) {
+		w.line("var err error")
+	}
+	w.line("proxy := Proxy%s{}", name)
+	if s.tagged() {
+		w.line("proxy.%s = %q", s.kindField(), name)
+	}
+	for _, f := range fields(s) {
+		src := recv + "." + f.Name
+		dst := "proxy." + f.Name
+		segment := fmt.Sprintf("%q", "."+jsonName(f.Name))
+		elemSegment := fmt.Sprintf("fmt.Sprintf(%q, %s)", "."+jsonName(f.Name)+"[%d]", index)
+		switch kindOf(f) {
+		case fieldNodeBase:
+			w.line("proxy.ProxyNodeBase = NewProxyNodeBase(%s.NodeBase)", recv)
+		case fieldNode:
+			w.line("%s, err = marshalField(NewNode(%s), %s)", dst, src, segment)
+			writeReturnErr(w, "nil, err")
+		case fieldNodes:
+			w.line("%s = make([]json.RawMessage, len(%s))", dst, src)
+			w.line("for %s, elem := range %s {", index, src)
+			w.line("%s[%s], err = marshalField(NewNode(elem), %s)", dst, index, elemSegment)
+			writeReturnErr(w, "nil, err")
+			w.line("}")
+		case fieldFodder:
+			w.line("%s = NewFodder(%s)", dst, src)
+		case fieldLocationRange:
+			w.line("%s = NewLocationRange(%s)", dst, src)
+		case fieldEnum:
+			w.line("%s = %s(%s)", dst, proxyTypeString(f), src)
+		case fieldStruct:
+			w.line("%s, err = marshalField(%s(%s), %s)", dst, f.Type.Name(), src, segment)
+			writeReturnErr(w, "nil, err")
+		case fieldStructPointer:
+			w.line("%s, err = marshalField((*%s)(%s), %s)", dst, f.Type.Elem().Name(), src, segment)
+			writeReturnErr(w, "nil, err")
+		case fieldStructs:
+			w.line("%s = make([]json.RawMessage, len(%s))", dst, src)
+			w.line("for %s, elem := range %s {", index, src)
+			w.line("%s[%s], err = marshalField(%s(elem), %s)", dst, index, f.Type.Elem().Name(), elemSegment)
+			writeReturnErr(w, "nil, err")
+			w.line("}")
+		default:
+			w.line("%s = %s", dst, src)
+		}
+	}
+	w.line("return json.Marshal(proxy)")
+	w.line("}")
+
+	w.line("")
+	w.line("func (%s *%s) UnmarshalJSON(data []byte) error {", recv, name)
+	w.line("var proxy Proxy%s", name)
+	w.line("err := json.Unmarshal(data, &proxy)")
+	writeReturnErr(w, "err")
+	for _, f := range fields(s) {
+		src := "proxy." + f.Name
+		dst := recv + "." + f.Name
+		segment := fmt.Sprintf("%q", "."+jsonName(f.Name))
+		elemSegment := fmt.Sprintf("fmt.Sprintf(%q, %s)", "."+jsonName(f.Name)+"[%d]", index)
+		switch kindOf(f) {
+		case fieldNodeBase:
+			w.line("%s.NodeBase = proxy.NodeBase()", recv)
+		case fieldNode:
+			w.line("%s, err = unmarshalNode(%s, %s)", dst, src, segment)
+			writeReturnErr(w, "err")
+		case fieldNodes:
+			w.line("%s = make(%s, len(%s))", dst, typeString(f.Type), src)
+			w.line("for %s, elem := range %s {", index, src)
+			w.line("%s[%s], err = unmarshalNode(elem, %s)", dst, index, elemSegment)
+			writeReturnErr(w, "err")
+			w.line("}")
+		case fieldFodder:
+			w.line("%s = %s.Fodder()", dst, src)
+		case fieldLocationRange:
+			w.line("%s = %s.LocationRange()", dst, src)
+		case fieldEnum:
+			w.line("%s = %s(%s)", dst, typeString(f.Type), src)
+		case fieldStruct:
+			w.line("err = unmarshalField(%s, (*%s)(&%s), %s)", src, f.Type.Name(), dst, segment)
+			writeReturnErr(w, "err")
+		case fieldStructPointer:
+			w.line("if !isNull(%s) {", src)
+			w.line("%s = &%s{}", dst, typeString(f.Type.Elem()))
+			w.line("err = unmarshalField(%s, (*%s)(%s), %s)", src, f.Type.Elem().Name(), dst, segment)
+			writeReturnErr(w, "err")
+			w.line("}")
+		case fieldStructs:
+			w.line("%s = make(%s, len(%s))", dst, typeString(f.Type), src)
+			w.line("for %s, elem := range %s {", index, src)
+			w.line("err = unmarshalField(elem, (*%s)(&%s[%s]), %s)", f.Type.Elem().Name(), dst, index, elemSegment)
+			writeReturnErr(w, "err")
+			w.line("}")
+		default:
+			w.line("%s = %s", dst, src)
+		}
+		if _, ok := legacy[f.Name]; ok {
+			w.line("if proxy.Legacy%s != nil {", f.Name)
+			if kindOf(f) == fieldEnum {
+				w.line("%s = %s(*proxy.Legacy%s)", dst, typeString(f.Type), f.Name)
+			} else {
+				w.line("%s = *proxy.Legacy%s", dst, f.Name)
+			}
+			w.line("}")
+		}
+	}
+	w.line("return nil")
+	w.line("}")
+}
+
+func hasChildren(s structType) bool {
+	for _, f := range fields(s) {
+		switch kindOf(f) {
+		case fieldNode, fieldNodes, fieldStruct, fieldStructPointer, fieldStructs:
+			return true
+		}
+	}
+	return false
+}
+
+func writeReturnErr(w *writer, results string) {
+	w.line("if err != nil {")
+	w.line("return %s", results)
+	w.line("}")
+}
+
+func GenerateLibsonnet() []byte {
+	w := &writer{}
+	w.line("// Code generated by go run ./internal/gen; DO NOT EDIT.")
+	w.line("")
+	w.line("local wrapArray(val) = if std.type(val) == 'array' then val else [val];")
+	w.line("")
+	w.line("local NodeBase = {")
+	w.line("  fodder(f):: self { fodder::: wrapArray(f) },")
+	w.line("};")
+	w.line("")
+	w.line("{")
+	for _, s := range structTypes() {
+		if !s.tagged() {
+			continue
+		}
+		base := ""
+		var setters []string
+		for _, f := range fields(s) {
+			switch kindOf(f) {
+			case fieldNodeBase:
+				base = "NodeBase "
+			case fieldFodder:
+				setters = append(setters, jsonName(f.Name))
+			}
+		}
+		w.line("  %s: %s{", s.name(), base)
+		w.line("    __kind__: '%s',", s.name())
+		for _, setter := range setters {
+			w.line("    %s(f):: self { %s::: wrapArray(f) },", setter, setter)
+		}
+		w.line("  },")
+	}
+	w.line("}")
+	return w.Bytes()
+}
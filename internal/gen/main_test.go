@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	src, err := GenerateGo()
+	require.NoError(t, err)
+	existing, err := os.ReadFile("../../jsonnet/ast_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(src), string(existing), "jsonnet/ast_gen.go is stale, run go generate ./jsonnet")
+
+	existing, err = os.ReadFile("../../ast.libsonnet")
+	require.NoError(t, err)
+	assert.Equal(t, string(GenerateLibsonnet()), string(existing), "ast.libsonnet is stale, run go generate ./jsonnet")
+}
+
+func TestJSONName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Id", expected: "id"},
+		{name: "IDFodder", expected: "idFodder"},
+		{name: "Expr1", expected: "expr1"},
+		{name: "TailStrictFodder", expected: "tailStrictFodder"},
+		{name: "ID", expected: "id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jsonName(tt.name))
+		})
+	}
+}
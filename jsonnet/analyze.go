@@ -0,0 +1,229 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+)
+
+type AnalysisResult struct {
+	Ast       any                `json:"ast"`
+	Unused    []AnalysisBinding  `json:"unused"`
+	Shadowed  []AnalysisShadow   `json:"shadowed"`
+	Undefined []AnalysisVariable `json:"undefined"`
+}
+
+type AnalysisBinding struct {
+	Name     string        `json:"name"`
+	Kind     BindingKind   `json:"kind"`
+	LocRange LocationRange `json:"locRange"`
+}
+
+type AnalysisShadow struct {
+	AnalysisBinding
+	Shadows AnalysisBinding `json:"shadows"`
+}
+
+type AnalysisVariable struct {
+	Name     string        `json:"name"`
+	LocRange LocationRange `json:"locRange"`
+}
+
+func Analyze(filename string, val string, options ParseOptions) (any, error) {
+	node, _, err := formatter.SnippetToRawAST(filename, val)
+	if err != nil {
+		return nil, err
+	}
+	scopes := ResolveScopes(node, val)
+	setFreeVars(node)
+	result := AnalysisResult{
+		Unused:    []AnalysisBinding{},
+		Shadowed:  []AnalysisShadow{},
+		Undefined: []AnalysisVariable{},
+	}
+	result.Ast, err = nodeToValue(node, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range scopes.Bindings {
+		if len(b.References) == 0 && !strings.HasPrefix(string(b.Name), "_") {
+			result.Unused = append(result.Unused, newAnalysisBinding(filename, b))
+		}
+		if b.Shadows != nil {
+			result.Shadowed = append(result.Shadowed, AnalysisShadow{
+				AnalysisBinding: newAnalysisBinding(filename, b),
+				Shadows:         newAnalysisBinding(filename, b.Shadows),
+			})
+		}
+	}
+	for _, v := range scopes.Undefined {
+		locRange := v.LocRange
+		locRange.File = nil
+		locRange.FileName = filename
+		result.Undefined = append(result.Undefined, AnalysisVariable{
+			Name:     string(v.Id),
+			LocRange: NewLocationRange(locRange),
+		})
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]any)
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, err
+	}
+	if options.Compact {
+		compact(res)
+	}
+	return res, nil
+}
+
+func newAnalysisBinding(filename string, b *Binding) AnalysisBinding {
+	locRange := b.LocRange()
+	locRange.FileName = filename
+	return AnalysisBinding{
+		Name:     string(b.Name),
+		Kind:     b.Kind,
+		LocRange: NewLocationRange(locRange),
+	}
+}
+
+type freeVarSet map[ast.Identifier]bool
+
+func (s freeVarSet) add(other freeVarSet) {
+	for id := range other {
+		s[id] = true
+	}
+}
+
+func (s freeVarSet) identifiers() ast.Identifiers {
+	res := make(ast.Identifiers, 0, len(s))
+	for id := range s {
+		res = append(res, id)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res
+}
+
+func setFreeVars(node ast.Node) freeVarSet {
+	if node == nil {
+		return freeVarSet{}
+	}
+	res := freeVarSet{}
+	switch n := node.(type) {
+	case *ast.Apply:
+		res.add(setFreeVars(n.Target))
+		for _, arg := range n.Arguments.Positional {
+			res.add(setFreeVars(arg.Expr))
+		}
+		for _, arg := range n.Arguments.Named {
+			res.add(setFreeVars(arg.Arg))
+		}
+	case *ast.ApplyBrace:
+		res.add(setFreeVars(n.Left))
+		res.add(setFreeVars(n.Right))
+	case *ast.Array:
+		for _, elem := range n.Elements {
+			res.add(setFreeVars(elem.Expr))
+		}
+	case *ast.ArrayComp:
+		res = forSpecFreeVars(&n.Spec, setFreeVars(n.Body))
+	case *ast.Assert:
+		res.add(setFreeVars(n.Cond))
+		res.add(setFreeVars(n.Message))
+		res.add(setFreeVars(n.Rest))
+	case *ast.Binary:
+		res.add(setFreeVars(n.Left))
+		res.add(setFreeVars(n.Right))
+	case *ast.Conditional:
+		res.add(setFreeVars(n.Cond))
+		res.add(setFreeVars(n.BranchTrue))
+		res.add(setFreeVars(n.BranchFalse))
+	case *ast.Error:
+		res.add(setFreeVars(n.Expr))
+	case *ast.Function:
+		for _, param := range n.Parameters {
+			res.add(setFreeVars(param.DefaultArg))
+		}
+		res.add(setFreeVars(n.Body))
+		for _, param := range n.Parameters {
+			delete(res, param.Name)
+		}
+	case *ast.Index:
+		res.add(setFreeVars(n.Target))
+		res.add(setFreeVars(n.Index))
+	case *ast.Slice:
+		res.add(setFreeVars(n.Target))
+		res.add(setFreeVars(n.BeginIndex))
+		res.add(setFreeVars(n.EndIndex))
+		res.add(setFreeVars(n.Step))
+	case *ast.Local:
+		for _, bind := range n.Binds {
+			if bind.Fun != nil {
+				res.add(setFreeVars(bind.Fun))
+			} else {
+				res.add(setFreeVars(bind.Body))
+			}
+		}
+		res.add(setFreeVars(n.Body))
+		for _, bind := range n.Binds {
+			delete(res, bind.Variable)
+		}
+	case *ast.Object:
+		res = objectFreeVars(n.Fields)
+	case *ast.ObjectComp:
+		res = forSpecFreeVars(&n.Spec, objectFreeVars(n.Fields))
+	case *ast.Parens:
+		res.add(setFreeVars(n.Inner))
+	case *ast.SuperIndex:
+		res.add(setFreeVars(n.Index))
+	case *ast.InSuper:
+		res.add(setFreeVars(n.Index))
+	case *ast.Unary:
+		res.add(setFreeVars(n.Expr))
+	case *ast.Var:
+		res[n.Id] = true
+	}
+	node.SetFreeVariables(res.identifiers())
+	return res
+}
+
+func objectFreeVars(fields ast.ObjectFields) freeVarSet {
+	res := freeVarSet{}
+	inner := freeVarSet{}
+	for _, field := range fields {
+		res.add(setFreeVars(field.Expr1))
+		if field.Method != nil {
+			inner.add(setFreeVars(field.Method))
+		} else {
+			inner.add(setFreeVars(field.Expr2))
+		}
+		inner.add(setFreeVars(field.Expr3))
+	}
+	for _, field := range fields {
+		if field.Kind == ast.ObjectLocal {
+			delete(inner, *field.Id)
+		}
+	}
+	res.add(inner)
+	return res
+}
+
+func forSpecFreeVars(spec *ast.ForSpec, body freeVarSet) freeVarSet {
+	res := body
+	for sp := spec; sp != nil; sp = sp.Outer {
+		for _, cond := range sp.Conditions {
+			res.add(setFreeVars(cond.Expr))
+		}
+		delete(res, sp.VarName)
+		res.add(setFreeVars(sp.Expr))
+	}
+	return res
+}
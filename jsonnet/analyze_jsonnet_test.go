@@ -0,0 +1,150 @@
+package jsonnet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeJsonnet(t *testing.T) {
+	tests := []struct {
+		name      string
+		jsonnet   string
+		unused    []string
+		shadowed  []string
+		undefined []string
+	}{
+		{
+			name:    "clean",
+			jsonnet: "local a = 1; function(b) a + b",
+		},
+		{
+			name:    "unused local",
+			jsonnet: "local a = 1, b = 2; a",
+			unused:  []string{"local b at 1:14"},
+		},
+		{
+			name:    "unused parameter",
+			jsonnet: "function(a, b, _c) a",
+			unused:  []string{"parameter b at 1:13"},
+		},
+		{
+			name:    "unused object local",
+			jsonnet: "{ local a = 1, b: 2 }",
+			unused:  []string{"objectLocal a at 1:9"},
+		},
+		{
+			name:    "unused for spec",
+			jsonnet: "[1 for x in [1, 2]]",
+			unused:  []string{"forSpec x at 1:8"},
+		},
+		{
+			name:     "shadowed",
+			jsonnet:  "local a = 1; local f(a) = a; f(a)",
+			shadowed: []string{"parameter a at 1:22 shadows local a at 1:7"},
+		},
+		{
+			name:      "undefined",
+			jsonnet:   "local a = 1; [a, b, std.length(c)]",
+			undefined: []string{"b at 1:18", "c at 1:32"},
+		},
+		{
+			name:      "object field names outside object scope",
+			jsonnet:   "{ local a = 'b', [a]: a }",
+			undefined: []string{"a at 1:19"},
+		},
+		{
+			name:    "recursive local",
+			jsonnet: "local f(n) = if n == 0 then 0 else f(n - 1); f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := AnalyzeJsonnet().Func([]any{tt.jsonnet, map[string]any{"compact": true}})
+			require.NoError(t, err)
+			analysis := result.(map[string]any)
+
+			unused := []string{}
+			for _, elem := range analysis["unused"].([]any) {
+				b := elem.(map[string]any)
+				unused = append(unused, b["kind"].(string)+" "+b["name"].(string)+" at "+beginOf(b))
+			}
+			shadowed := []string{}
+			for _, elem := range analysis["shadowed"].([]any) {
+				b := elem.(map[string]any)
+				s := b["shadows"].(map[string]any)
+				shadowed = append(shadowed, b["kind"].(string)+" "+b["name"].(string)+" at "+beginOf(b)+" shadows "+s["kind"].(string)+" "+s["name"].(string)+" at "+beginOf(s))
+			}
+			undefined := []string{}
+			for _, elem := range analysis["undefined"].([]any) {
+				v := elem.(map[string]any)
+				undefined = append(undefined, v["name"].(string)+" at "+beginOf(v))
+			}
+
+			assert.ElementsMatch(t, tt.unused, unused)
+			assert.ElementsMatch(t, tt.shadowed, shadowed)
+			assert.ElementsMatch(t, tt.undefined, undefined)
+		})
+	}
+}
+
+func beginOf(val map[string]any) string {
+	begin := val["locRange"].(map[string]any)["begin"].(map[string]any)
+	return fmt.Sprintf("%v:%v", begin["line"], begin["column"])
+}
+
+func TestAnalyzeJsonnetFreeVars(t *testing.T) {
+	result, err := AnalyzeJsonnet().Func([]any{"local a = 1; [a + b, function(c) c + d]"})
+	require.NoError(t, err)
+
+	root := result.(map[string]any)["ast"].(map[string]any)
+	assert.Equal(t, []any{"b", "d"}, root["freeVars"])
+
+	array := root["body"].(map[string]any)
+	assert.Equal(t, []any{"a", "b", "d"}, array["freeVars"])
+
+	function := array["elements"].([]any)[1].(map[string]any)["expr"].(map[string]any)
+	assert.Equal(t, []any{"d"}, function["freeVars"])
+	assert.Equal(t, []any{"c", "d"}, function["body"].(map[string]any)["freeVars"])
+}
+
+func TestAnalyzeJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "jsonnet must be provided",
+		},
+		{
+			name:     "non-string argument",
+			input:    []any{123},
+			expected: "jsonnet must be a string",
+		},
+		{
+			name:     "invalid options",
+			input:    []any{"null", map[string]any{"foo": true}},
+			expected: "invalid options",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"local a = 1;"},
+			expected: "main.jsonnet:1:13",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := AnalyzeJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
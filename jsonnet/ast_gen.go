@@ -0,0 +1,2075 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+func (n Node) MarshalJSON() ([]byte, error) {
+	if n.Node == nil {
+		return []byte("null"), nil
+	}
+	var proxy any
+	switch v := n.Node.(type) {
+	case *ast.Apply:
+		proxy = Apply(*v)
+	case *ast.ApplyBrace:
+		proxy = ApplyBrace(*v)
+	case *ast.Array:
+		proxy = Array(*v)
+	case *ast.ArrayComp:
+		proxy = ArrayComp(*v)
+	case *ast.Assert:
+		proxy = Assert(*v)
+	case *ast.Binary:
+		proxy = Binary(*v)
+	case *ast.Conditional:
+		proxy = Conditional(*v)
+	case *ast.DesugaredObject:
+		proxy = DesugaredObject(*v)
+	case *ast.Dollar:
+		proxy = Dollar(*v)
+	case *ast.Error:
+		proxy = Error(*v)
+	case *ast.Function:
+		proxy = Function(*v)
+	case *ast.Import:
+		proxy = Import(*v)
+	case *ast.ImportBin:
+		proxy = ImportBin(*v)
+	case *ast.ImportStr:
+		proxy = ImportStr(*v)
+	case *ast.InSuper:
+		proxy = InSuper(*v)
+	case *ast.Index:
+		proxy = Index(*v)
+	case *ast.LiteralBoolean:
+		proxy = LiteralBoolean(*v)
+	case *ast.LiteralNull:
+		proxy = LiteralNull(*v)
+	case *ast.LiteralNumber:
+		proxy = LiteralNumber(*v)
+	case *ast.LiteralString:
+		proxy = LiteralString(*v)
+	case *ast.Local:
+		proxy = Local(*v)
+	case *ast.Object:
+		proxy = Object(*v)
+	case *ast.ObjectComp:
+		proxy = ObjectComp(*v)
+	case *ast.Parens:
+		proxy = Parens(*v)
+	case *ast.Self:
+		proxy = Self(*v)
+	case *ast.Slice:
+		proxy = Slice(*v)
+	case *ast.SuperIndex:
+		proxy = SuperIndex(*v)
+	case *ast.Unary:
+		proxy = Unary(*v)
+	case *ast.Var:
+		proxy = Var(*v)
+	default:
+		return nil, &UnsupportedNodeError{Kind: fmt.Sprintf("%T", v)}
+	}
+	b, err := json.Marshal(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (n *Node) UnmarshalJSON(data []byte) error {
+	k := struct {
+		Kind string `json:"__kind__"`
+	}{}
+	err := json.Unmarshal(data, &k)
+	if err != nil {
+		return err
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	switch k.Kind {
+	case "Apply":
+		var v Apply
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Apply(v)
+		n.Node = &node
+	case "ApplyBrace":
+		var v ApplyBrace
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.ApplyBrace(v)
+		n.Node = &node
+	case "Array":
+		var v Array
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Array(v)
+		n.Node = &node
+	case "ArrayComp":
+		var v ArrayComp
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.ArrayComp(v)
+		n.Node = &node
+	case "Assert":
+		var v Assert
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Assert(v)
+		n.Node = &node
+	case "Binary":
+		var v Binary
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Binary(v)
+		n.Node = &node
+	case "Conditional":
+		var v Conditional
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Conditional(v)
+		n.Node = &node
+	case "DesugaredObject":
+		var v DesugaredObject
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.DesugaredObject(v)
+		n.Node = &node
+	case "Dollar":
+		var v Dollar
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Dollar(v)
+		n.Node = &node
+	case "Error":
+		var v Error
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Error(v)
+		n.Node = &node
+	case "Function":
+		var v Function
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Function(v)
+		n.Node = &node
+	case "Import":
+		var v Import
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Import(v)
+		n.Node = &node
+	case "ImportBin":
+		var v ImportBin
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.ImportBin(v)
+		n.Node = &node
+	case "ImportStr":
+		var v ImportStr
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.ImportStr(v)
+		n.Node = &node
+	case "InSuper":
+		var v InSuper
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.InSuper(v)
+		n.Node = &node
+	case "Index":
+		var v Index
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Index(v)
+		n.Node = &node
+	case "LiteralBoolean":
+		var v LiteralBoolean
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.LiteralBoolean(v)
+		n.Node = &node
+	case "LiteralNull":
+		var v LiteralNull
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.LiteralNull(v)
+		n.Node = &node
+	case "LiteralNumber":
+		var v LiteralNumber
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.LiteralNumber(v)
+		n.Node = &node
+	case "LiteralString":
+		var v LiteralString
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.LiteralString(v)
+		n.Node = &node
+	case "Local":
+		var v Local
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Local(v)
+		n.Node = &node
+	case "Object":
+		var v Object
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Object(v)
+		n.Node = &node
+	case "ObjectComp":
+		var v ObjectComp
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.ObjectComp(v)
+		n.Node = &node
+	case "Parens":
+		var v Parens
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Parens(v)
+		n.Node = &node
+	case "Self":
+		var v Self
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Self(v)
+		n.Node = &node
+	case "Slice":
+		var v Slice
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Slice(v)
+		n.Node = &node
+	case "SuperIndex":
+		var v SuperIndex
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.SuperIndex(v)
+		n.Node = &node
+	case "Unary":
+		var v Unary
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Unary(v)
+		n.Node = &node
+	case "Var":
+		var v Var
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+		node := ast.Var(v)
+		n.Node = &node
+	default:
+		return &UnsupportedNodeError{Kind: k.Kind}
+	}
+	return nil
+}
+
+type Apply ast.Apply
+
+type ProxyApply struct {
+	Kind             string          `json:"__kind__"`
+	Target           json.RawMessage `json:"target"`
+	FodderLeft       Fodder          `json:"fodderLeft"`
+	Arguments        json.RawMessage `json:"arguments"`
+	FodderRight      Fodder          `json:"fodderRight"`
+	TailStrictFodder Fodder          `json:"tailStrictFodder"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+	TailStrict    bool `json:"tailStrict"`
+}
+
+func (a Apply) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyApply{}
+	proxy.Kind = "Apply"
+	proxy.Target, err = marshalField(NewNode(a.Target), ".target")
+	if err != nil {
+		return nil, err
+	}
+	proxy.FodderLeft = NewFodder(a.FodderLeft)
+	proxy.Arguments, err = marshalField(Arguments(a.Arguments), ".arguments")
+	if err != nil {
+		return nil, err
+	}
+	proxy.FodderRight = NewFodder(a.FodderRight)
+	proxy.TailStrictFodder = NewFodder(a.TailStrictFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(a.NodeBase)
+	proxy.TrailingComma = a.TrailingComma
+	proxy.TailStrict = a.TailStrict
+	return json.Marshal(proxy)
+}
+
+func (a *Apply) UnmarshalJSON(data []byte) error {
+	var proxy ProxyApply
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Target, err = unmarshalNode(proxy.Target, ".target")
+	if err != nil {
+		return err
+	}
+	a.FodderLeft = proxy.FodderLeft.Fodder()
+	err = unmarshalField(proxy.Arguments, (*Arguments)(&a.Arguments), ".arguments")
+	if err != nil {
+		return err
+	}
+	a.FodderRight = proxy.FodderRight.Fodder()
+	a.TailStrictFodder = proxy.TailStrictFodder.Fodder()
+	a.NodeBase = proxy.NodeBase()
+	a.TrailingComma = proxy.TrailingComma
+	a.TailStrict = proxy.TailStrict
+	return nil
+}
+
+type ApplyBrace ast.ApplyBrace
+
+type ProxyApplyBrace struct {
+	Kind  string          `json:"__kind__"`
+	Left  json.RawMessage `json:"left"`
+	Right json.RawMessage `json:"right"`
+	ProxyNodeBase
+}
+
+func (a ApplyBrace) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyApplyBrace{}
+	proxy.Kind = "ApplyBrace"
+	proxy.Left, err = marshalField(NewNode(a.Left), ".left")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Right, err = marshalField(NewNode(a.Right), ".right")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(a.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (a *ApplyBrace) UnmarshalJSON(data []byte) error {
+	var proxy ProxyApplyBrace
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Left, err = unmarshalNode(proxy.Left, ".left")
+	if err != nil {
+		return err
+	}
+	a.Right, err = unmarshalNode(proxy.Right, ".right")
+	if err != nil {
+		return err
+	}
+	a.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Arguments ast.Arguments
+
+type ProxyArguments struct {
+	Positional []json.RawMessage `json:"positional"`
+	Named      []json.RawMessage `json:"named"`
+}
+
+func (a Arguments) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyArguments{}
+	proxy.Positional = make([]json.RawMessage, len(a.Positional))
+	for i, elem := range a.Positional {
+		proxy.Positional[i], err = marshalField(CommaSeparatedExpr(elem), fmt.Sprintf(".positional[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.Named = make([]json.RawMessage, len(a.Named))
+	for i, elem := range a.Named {
+		proxy.Named[i], err = marshalField(NamedArgument(elem), fmt.Sprintf(".named[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(proxy)
+}
+
+func (a *Arguments) UnmarshalJSON(data []byte) error {
+	var proxy ProxyArguments
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Positional = make([]ast.CommaSeparatedExpr, len(proxy.Positional))
+	for i, elem := range proxy.Positional {
+		err = unmarshalField(elem, (*CommaSeparatedExpr)(&a.Positional[i]), fmt.Sprintf(".positional[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	a.Named = make([]ast.NamedArgument, len(proxy.Named))
+	for i, elem := range proxy.Named {
+		err = unmarshalField(elem, (*NamedArgument)(&a.Named[i]), fmt.Sprintf(".named[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Array ast.Array
+
+type ProxyArray struct {
+	Kind        string            `json:"__kind__"`
+	Elements    []json.RawMessage `json:"elements"`
+	CloseFodder Fodder            `json:"closeFodder"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+}
+
+func (a Array) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyArray{}
+	proxy.Kind = "Array"
+	proxy.Elements = make([]json.RawMessage, len(a.Elements))
+	for i, elem := range a.Elements {
+		proxy.Elements[i], err = marshalField(CommaSeparatedExpr(elem), fmt.Sprintf(".elements[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.CloseFodder = NewFodder(a.CloseFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(a.NodeBase)
+	proxy.TrailingComma = a.TrailingComma
+	return json.Marshal(proxy)
+}
+
+func (a *Array) UnmarshalJSON(data []byte) error {
+	var proxy ProxyArray
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Elements = make([]ast.CommaSeparatedExpr, len(proxy.Elements))
+	for i, elem := range proxy.Elements {
+		err = unmarshalField(elem, (*CommaSeparatedExpr)(&a.Elements[i]), fmt.Sprintf(".elements[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	a.CloseFodder = proxy.CloseFodder.Fodder()
+	a.NodeBase = proxy.NodeBase()
+	a.TrailingComma = proxy.TrailingComma
+	return nil
+}
+
+type ArrayComp ast.ArrayComp
+
+type ProxyArrayComp struct {
+	Kind                string          `json:"__kind__"`
+	Body                json.RawMessage `json:"body"`
+	TrailingCommaFodder Fodder          `json:"trailingCommaFodder"`
+	Spec                json.RawMessage `json:"spec"`
+	CloseFodder         Fodder          `json:"closeFodder"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+}
+
+func (a ArrayComp) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyArrayComp{}
+	proxy.Kind = "ArrayComp"
+	proxy.Body, err = marshalField(NewNode(a.Body), ".body")
+	if err != nil {
+		return nil, err
+	}
+	proxy.TrailingCommaFodder = NewFodder(a.TrailingCommaFodder)
+	proxy.Spec, err = marshalField(ForSpec(a.Spec), ".spec")
+	if err != nil {
+		return nil, err
+	}
+	proxy.CloseFodder = NewFodder(a.CloseFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(a.NodeBase)
+	proxy.TrailingComma = a.TrailingComma
+	return json.Marshal(proxy)
+}
+
+func (a *ArrayComp) UnmarshalJSON(data []byte) error {
+	var proxy ProxyArrayComp
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Body, err = unmarshalNode(proxy.Body, ".body")
+	if err != nil {
+		return err
+	}
+	a.TrailingCommaFodder = proxy.TrailingCommaFodder.Fodder()
+	err = unmarshalField(proxy.Spec, (*ForSpec)(&a.Spec), ".spec")
+	if err != nil {
+		return err
+	}
+	a.CloseFodder = proxy.CloseFodder.Fodder()
+	a.NodeBase = proxy.NodeBase()
+	a.TrailingComma = proxy.TrailingComma
+	return nil
+}
+
+type Assert ast.Assert
+
+type ProxyAssert struct {
+	Kind            string          `json:"__kind__"`
+	Cond            json.RawMessage `json:"cond"`
+	Message         json.RawMessage `json:"message"`
+	Rest            json.RawMessage `json:"rest"`
+	ColonFodder     Fodder          `json:"colonFodder"`
+	SemicolonFodder Fodder          `json:"semicolonFodder"`
+	ProxyNodeBase
+}
+
+func (a Assert) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyAssert{}
+	proxy.Kind = "Assert"
+	proxy.Cond, err = marshalField(NewNode(a.Cond), ".cond")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Message, err = marshalField(NewNode(a.Message), ".message")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Rest, err = marshalField(NewNode(a.Rest), ".rest")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ColonFodder = NewFodder(a.ColonFodder)
+	proxy.SemicolonFodder = NewFodder(a.SemicolonFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(a.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (a *Assert) UnmarshalJSON(data []byte) error {
+	var proxy ProxyAssert
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	a.Cond, err = unmarshalNode(proxy.Cond, ".cond")
+	if err != nil {
+		return err
+	}
+	a.Message, err = unmarshalNode(proxy.Message, ".message")
+	if err != nil {
+		return err
+	}
+	a.Rest, err = unmarshalNode(proxy.Rest, ".rest")
+	if err != nil {
+		return err
+	}
+	a.ColonFodder = proxy.ColonFodder.Fodder()
+	a.SemicolonFodder = proxy.SemicolonFodder.Fodder()
+	a.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Binary ast.Binary
+
+type ProxyBinary struct {
+	Kind     string          `json:"__kind__"`
+	Right    json.RawMessage `json:"right"`
+	Left     json.RawMessage `json:"left"`
+	OpFodder Fodder          `json:"opFodder"`
+	ProxyNodeBase
+	Op BinaryOp `json:"op"`
+}
+
+func (b Binary) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyBinary{}
+	proxy.Kind = "Binary"
+	proxy.Right, err = marshalField(NewNode(b.Right), ".right")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Left, err = marshalField(NewNode(b.Left), ".left")
+	if err != nil {
+		return nil, err
+	}
+	proxy.OpFodder = NewFodder(b.OpFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(b.NodeBase)
+	proxy.Op = BinaryOp(b.Op)
+	return json.Marshal(proxy)
+}
+
+func (b *Binary) UnmarshalJSON(data []byte) error {
+	var proxy ProxyBinary
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	b.Right, err = unmarshalNode(proxy.Right, ".right")
+	if err != nil {
+		return err
+	}
+	b.Left, err = unmarshalNode(proxy.Left, ".left")
+	if err != nil {
+		return err
+	}
+	b.OpFodder = proxy.OpFodder.Fodder()
+	b.NodeBase = proxy.NodeBase()
+	b.Op = ast.BinaryOp(proxy.Op)
+	return nil
+}
+
+type CommaSeparatedExpr ast.CommaSeparatedExpr
+
+type ProxyCommaSeparatedExpr struct {
+	Kind        string          `json:"__kind__"`
+	Expr        json.RawMessage `json:"expr"`
+	CommaFodder Fodder          `json:"commaFodder"`
+}
+
+func (c CommaSeparatedExpr) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyCommaSeparatedExpr{}
+	proxy.Kind = "CommaSeparatedExpr"
+	proxy.Expr, err = marshalField(NewNode(c.Expr), ".expr")
+	if err != nil {
+		return nil, err
+	}
+	proxy.CommaFodder = NewFodder(c.CommaFodder)
+	return json.Marshal(proxy)
+}
+
+func (c *CommaSeparatedExpr) UnmarshalJSON(data []byte) error {
+	var proxy ProxyCommaSeparatedExpr
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	c.Expr, err = unmarshalNode(proxy.Expr, ".expr")
+	if err != nil {
+		return err
+	}
+	c.CommaFodder = proxy.CommaFodder.Fodder()
+	return nil
+}
+
+type Conditional ast.Conditional
+
+type ProxyConditional struct {
+	Kind        string          `json:"__kind__"`
+	Cond        json.RawMessage `json:"cond"`
+	BranchTrue  json.RawMessage `json:"branchTrue"`
+	BranchFalse json.RawMessage `json:"branchFalse"`
+	ThenFodder  Fodder          `json:"thenFodder"`
+	ElseFodder  Fodder          `json:"elseFodder"`
+	ProxyNodeBase
+}
+
+func (c Conditional) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyConditional{}
+	proxy.Kind = "Conditional"
+	proxy.Cond, err = marshalField(NewNode(c.Cond), ".cond")
+	if err != nil {
+		return nil, err
+	}
+	proxy.BranchTrue, err = marshalField(NewNode(c.BranchTrue), ".branchTrue")
+	if err != nil {
+		return nil, err
+	}
+	proxy.BranchFalse, err = marshalField(NewNode(c.BranchFalse), ".branchFalse")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ThenFodder = NewFodder(c.ThenFodder)
+	proxy.ElseFodder = NewFodder(c.ElseFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(c.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (c *Conditional) UnmarshalJSON(data []byte) error {
+	var proxy ProxyConditional
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	c.Cond, err = unmarshalNode(proxy.Cond, ".cond")
+	if err != nil {
+		return err
+	}
+	c.BranchTrue, err = unmarshalNode(proxy.BranchTrue, ".branchTrue")
+	if err != nil {
+		return err
+	}
+	c.BranchFalse, err = unmarshalNode(proxy.BranchFalse, ".branchFalse")
+	if err != nil {
+		return err
+	}
+	c.ThenFodder = proxy.ThenFodder.Fodder()
+	c.ElseFodder = proxy.ElseFodder.Fodder()
+	c.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type DesugaredObject ast.DesugaredObject
+
+type ProxyDesugaredObject struct {
+	Kind    string            `json:"__kind__"`
+	Asserts []json.RawMessage `json:"asserts"`
+	Fields  []json.RawMessage `json:"fields"`
+	Locals  []json.RawMessage `json:"locals"`
+	ProxyNodeBase
+}
+
+func (d DesugaredObject) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyDesugaredObject{}
+	proxy.Kind = "DesugaredObject"
+	proxy.Asserts = make([]json.RawMessage, len(d.Asserts))
+	for i, elem := range d.Asserts {
+		proxy.Asserts[i], err = marshalField(NewNode(elem), fmt.Sprintf(".asserts[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.Fields = make([]json.RawMessage, len(d.Fields))
+	for i, elem := range d.Fields {
+		proxy.Fields[i], err = marshalField(DesugaredObjectField(elem), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.Locals = make([]json.RawMessage, len(d.Locals))
+	for i, elem := range d.Locals {
+		proxy.Locals[i], err = marshalField(LocalBind(elem), fmt.Sprintf(".locals[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(d.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (d *DesugaredObject) UnmarshalJSON(data []byte) error {
+	var proxy ProxyDesugaredObject
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	d.Asserts = make(ast.Nodes, len(proxy.Asserts))
+	for i, elem := range proxy.Asserts {
+		d.Asserts[i], err = unmarshalNode(elem, fmt.Sprintf(".asserts[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	d.Fields = make(ast.DesugaredObjectFields, len(proxy.Fields))
+	for i, elem := range proxy.Fields {
+		err = unmarshalField(elem, (*DesugaredObjectField)(&d.Fields[i]), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	d.Locals = make(ast.LocalBinds, len(proxy.Locals))
+	for i, elem := range proxy.Locals {
+		err = unmarshalField(elem, (*LocalBind)(&d.Locals[i]), fmt.Sprintf(".locals[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	d.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type DesugaredObjectField ast.DesugaredObjectField
+
+type ProxyDesugaredObjectField struct {
+	Kind      string          `json:"__kind__"`
+	Name      json.RawMessage `json:"name"`
+	Body      json.RawMessage `json:"body"`
+	LocRange  LocationRange   `json:"locRange"`
+	Hide      ObjectFieldHide `json:"hide"`
+	PlusSuper bool            `json:"plusSuper"`
+}
+
+func (d DesugaredObjectField) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyDesugaredObjectField{}
+	proxy.Kind = "DesugaredObjectField"
+	proxy.Name, err = marshalField(NewNode(d.Name), ".name")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Body, err = marshalField(NewNode(d.Body), ".body")
+	if err != nil {
+		return nil, err
+	}
+	proxy.LocRange = NewLocationRange(d.LocRange)
+	proxy.Hide = ObjectFieldHide(d.Hide)
+	proxy.PlusSuper = d.PlusSuper
+	return json.Marshal(proxy)
+}
+
+func (d *DesugaredObjectField) UnmarshalJSON(data []byte) error {
+	var proxy ProxyDesugaredObjectField
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	d.Name, err = unmarshalNode(proxy.Name, ".name")
+	if err != nil {
+		return err
+	}
+	d.Body, err = unmarshalNode(proxy.Body, ".body")
+	if err != nil {
+		return err
+	}
+	d.LocRange = proxy.LocRange.LocationRange()
+	d.Hide = ast.ObjectFieldHide(proxy.Hide)
+	d.PlusSuper = proxy.PlusSuper
+	return nil
+}
+
+type Dollar ast.Dollar
+
+type ProxyDollar struct {
+	Kind string `json:"__kind__"`
+	ProxyNodeBase
+}
+
+func (d Dollar) MarshalJSON() ([]byte, error) {
+	proxy := ProxyDollar{}
+	proxy.Kind = "Dollar"
+	proxy.ProxyNodeBase = NewProxyNodeBase(d.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (d *Dollar) UnmarshalJSON(data []byte) error {
+	var proxy ProxyDollar
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	d.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Error ast.Error
+
+type ProxyError struct {
+	Kind string          `json:"__kind__"`
+	Expr json.RawMessage `json:"expr"`
+	ProxyNodeBase
+}
+
+func (e Error) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyError{}
+	proxy.Kind = "Error"
+	proxy.Expr, err = marshalField(NewNode(e.Expr), ".expr")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(e.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var proxy ProxyError
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	e.Expr, err = unmarshalNode(proxy.Expr, ".expr")
+	if err != nil {
+		return err
+	}
+	e.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type ForSpec ast.ForSpec
+
+type ProxyForSpec struct {
+	Kind       string            `json:"__kind__"`
+	ForFodder  Fodder            `json:"forFodder"`
+	VarFodder  Fodder            `json:"varFodder"`
+	Conditions []json.RawMessage `json:"conditions"`
+	Outer      json.RawMessage   `json:"outer"`
+	Expr       json.RawMessage   `json:"expr"`
+	VarName    ast.Identifier    `json:"varName"`
+	InFodder   Fodder            `json:"inFodder"`
+}
+
+func (f ForSpec) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyForSpec{}
+	proxy.Kind = "ForSpec"
+	proxy.ForFodder = NewFodder(f.ForFodder)
+	proxy.VarFodder = NewFodder(f.VarFodder)
+	proxy.Conditions = make([]json.RawMessage, len(f.Conditions))
+	for i, elem := range f.Conditions {
+		proxy.Conditions[i], err = marshalField(IfSpec(elem), fmt.Sprintf(".conditions[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.Outer, err = marshalField((*ForSpec)(f.Outer), ".outer")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Expr, err = marshalField(NewNode(f.Expr), ".expr")
+	if err != nil {
+		return nil, err
+	}
+	proxy.VarName = f.VarName
+	proxy.InFodder = NewFodder(f.InFodder)
+	return json.Marshal(proxy)
+}
+
+func (f *ForSpec) UnmarshalJSON(data []byte) error {
+	var proxy ProxyForSpec
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	f.ForFodder = proxy.ForFodder.Fodder()
+	f.VarFodder = proxy.VarFodder.Fodder()
+	f.Conditions = make([]ast.IfSpec, len(proxy.Conditions))
+	for i, elem := range proxy.Conditions {
+		err = unmarshalField(elem, (*IfSpec)(&f.Conditions[i]), fmt.Sprintf(".conditions[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	if !isNull(proxy.Outer) {
+		f.Outer = &ast.ForSpec{}
+		err = unmarshalField(proxy.Outer, (*ForSpec)(f.Outer), ".outer")
+		if err != nil {
+			return err
+		}
+	}
+	f.Expr, err = unmarshalNode(proxy.Expr, ".expr")
+	if err != nil {
+		return err
+	}
+	f.VarName = proxy.VarName
+	f.InFodder = proxy.InFodder.Fodder()
+	return nil
+}
+
+type Function ast.Function
+
+type ProxyFunction struct {
+	Kind             string            `json:"__kind__"`
+	ParenLeftFodder  Fodder            `json:"parenLeftFodder"`
+	ParenRightFodder Fodder            `json:"parenRightFodder"`
+	Body             json.RawMessage   `json:"body"`
+	Parameters       []json.RawMessage `json:"parameters"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+}
+
+func (f Function) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyFunction{}
+	proxy.Kind = "Function"
+	proxy.ParenLeftFodder = NewFodder(f.ParenLeftFodder)
+	proxy.ParenRightFodder = NewFodder(f.ParenRightFodder)
+	proxy.Body, err = marshalField(NewNode(f.Body), ".body")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Parameters = make([]json.RawMessage, len(f.Parameters))
+	for i, elem := range f.Parameters {
+		proxy.Parameters[i], err = marshalField(Parameter(elem), fmt.Sprintf(".parameters[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(f.NodeBase)
+	proxy.TrailingComma = f.TrailingComma
+	return json.Marshal(proxy)
+}
+
+func (f *Function) UnmarshalJSON(data []byte) error {
+	var proxy ProxyFunction
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	f.ParenLeftFodder = proxy.ParenLeftFodder.Fodder()
+	f.ParenRightFodder = proxy.ParenRightFodder.Fodder()
+	f.Body, err = unmarshalNode(proxy.Body, ".body")
+	if err != nil {
+		return err
+	}
+	f.Parameters = make([]ast.Parameter, len(proxy.Parameters))
+	for i, elem := range proxy.Parameters {
+		err = unmarshalField(elem, (*Parameter)(&f.Parameters[i]), fmt.Sprintf(".parameters[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	f.NodeBase = proxy.NodeBase()
+	f.TrailingComma = proxy.TrailingComma
+	return nil
+}
+
+type IfSpec ast.IfSpec
+
+type ProxyIfSpec struct {
+	Kind     string          `json:"__kind__"`
+	Expr     json.RawMessage `json:"expr"`
+	IfFodder Fodder          `json:"ifFodder"`
+}
+
+func (i IfSpec) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyIfSpec{}
+	proxy.Kind = "IfSpec"
+	proxy.Expr, err = marshalField(NewNode(i.Expr), ".expr")
+	if err != nil {
+		return nil, err
+	}
+	proxy.IfFodder = NewFodder(i.IfFodder)
+	return json.Marshal(proxy)
+}
+
+func (i *IfSpec) UnmarshalJSON(data []byte) error {
+	var proxy ProxyIfSpec
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	i.Expr, err = unmarshalNode(proxy.Expr, ".expr")
+	if err != nil {
+		return err
+	}
+	i.IfFodder = proxy.IfFodder.Fodder()
+	return nil
+}
+
+type Import ast.Import
+
+type ProxyImport struct {
+	Kind string          `json:"__kind__"`
+	File json.RawMessage `json:"file"`
+	ProxyNodeBase
+}
+
+func (i Import) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyImport{}
+	proxy.Kind = "Import"
+	proxy.File, err = marshalField((*LiteralString)(i.File), ".file")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(i.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (i *Import) UnmarshalJSON(data []byte) error {
+	var proxy ProxyImport
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	if !isNull(proxy.File) {
+		i.File = &ast.LiteralString{}
+		err = unmarshalField(proxy.File, (*LiteralString)(i.File), ".file")
+		if err != nil {
+			return err
+		}
+	}
+	i.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type ImportBin ast.ImportBin
+
+type ProxyImportBin struct {
+	Kind string          `json:"__kind__"`
+	File json.RawMessage `json:"file"`
+	ProxyNodeBase
+}
+
+func (i ImportBin) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyImportBin{}
+	proxy.Kind = "ImportBin"
+	proxy.File, err = marshalField((*LiteralString)(i.File), ".file")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(i.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (i *ImportBin) UnmarshalJSON(data []byte) error {
+	var proxy ProxyImportBin
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	if !isNull(proxy.File) {
+		i.File = &ast.LiteralString{}
+		err = unmarshalField(proxy.File, (*LiteralString)(i.File), ".file")
+		if err != nil {
+			return err
+		}
+	}
+	i.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type ImportStr ast.ImportStr
+
+type ProxyImportStr struct {
+	Kind string          `json:"__kind__"`
+	File json.RawMessage `json:"file"`
+	ProxyNodeBase
+}
+
+func (i ImportStr) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyImportStr{}
+	proxy.Kind = "ImportStr"
+	proxy.File, err = marshalField((*LiteralString)(i.File), ".file")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(i.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (i *ImportStr) UnmarshalJSON(data []byte) error {
+	var proxy ProxyImportStr
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	if !isNull(proxy.File) {
+		i.File = &ast.LiteralString{}
+		err = unmarshalField(proxy.File, (*LiteralString)(i.File), ".file")
+		if err != nil {
+			return err
+		}
+	}
+	i.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type InSuper ast.InSuper
+
+type ProxyInSuper struct {
+	Kind        string          `json:"__kind__"`
+	Index       json.RawMessage `json:"index"`
+	InFodder    Fodder          `json:"inFodder"`
+	SuperFodder Fodder          `json:"superFodder"`
+	ProxyNodeBase
+}
+
+func (i InSuper) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyInSuper{}
+	proxy.Kind = "InSuper"
+	proxy.Index, err = marshalField(NewNode(i.Index), ".index")
+	if err != nil {
+		return nil, err
+	}
+	proxy.InFodder = NewFodder(i.InFodder)
+	proxy.SuperFodder = NewFodder(i.SuperFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(i.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (i *InSuper) UnmarshalJSON(data []byte) error {
+	var proxy ProxyInSuper
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	i.Index, err = unmarshalNode(proxy.Index, ".index")
+	if err != nil {
+		return err
+	}
+	i.InFodder = proxy.InFodder.Fodder()
+	i.SuperFodder = proxy.SuperFodder.Fodder()
+	i.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Index ast.Index
+
+type ProxyIndex struct {
+	Kind               string          `json:"__kind__"`
+	Target             json.RawMessage `json:"target"`
+	Index              json.RawMessage `json:"index"`
+	RightBracketFodder Fodder          `json:"rightBracketFodder"`
+	LeftBracketFodder  Fodder          `json:"leftBracketFodder"`
+	Id                 *ast.Identifier `json:"id"`
+	ProxyNodeBase
+}
+
+func (i Index) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyIndex{}
+	proxy.Kind = "Index"
+	proxy.Target, err = marshalField(NewNode(i.Target), ".target")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Index, err = marshalField(NewNode(i.Index), ".index")
+	if err != nil {
+		return nil, err
+	}
+	proxy.RightBracketFodder = NewFodder(i.RightBracketFodder)
+	proxy.LeftBracketFodder = NewFodder(i.LeftBracketFodder)
+	proxy.Id = i.Id
+	proxy.ProxyNodeBase = NewProxyNodeBase(i.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (i *Index) UnmarshalJSON(data []byte) error {
+	var proxy ProxyIndex
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	i.Target, err = unmarshalNode(proxy.Target, ".target")
+	if err != nil {
+		return err
+	}
+	i.Index, err = unmarshalNode(proxy.Index, ".index")
+	if err != nil {
+		return err
+	}
+	i.RightBracketFodder = proxy.RightBracketFodder.Fodder()
+	i.LeftBracketFodder = proxy.LeftBracketFodder.Fodder()
+	i.Id = proxy.Id
+	i.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type LiteralBoolean ast.LiteralBoolean
+
+type ProxyLiteralBoolean struct {
+	Kind string `json:"__kind__"`
+	ProxyNodeBase
+	Value bool `json:"value"`
+}
+
+func (l LiteralBoolean) MarshalJSON() ([]byte, error) {
+	proxy := ProxyLiteralBoolean{}
+	proxy.Kind = "LiteralBoolean"
+	proxy.ProxyNodeBase = NewProxyNodeBase(l.NodeBase)
+	proxy.Value = l.Value
+	return json.Marshal(proxy)
+}
+
+func (l *LiteralBoolean) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLiteralBoolean
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.NodeBase = proxy.NodeBase()
+	l.Value = proxy.Value
+	return nil
+}
+
+type LiteralNull ast.LiteralNull
+
+type ProxyLiteralNull struct {
+	Kind string `json:"__kind__"`
+	ProxyNodeBase
+}
+
+func (l LiteralNull) MarshalJSON() ([]byte, error) {
+	proxy := ProxyLiteralNull{}
+	proxy.Kind = "LiteralNull"
+	proxy.ProxyNodeBase = NewProxyNodeBase(l.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (l *LiteralNull) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLiteralNull
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type LiteralNumber ast.LiteralNumber
+
+type ProxyLiteralNumber struct {
+	Kind           string `json:"__kind__"`
+	OriginalString string `json:"originalString"`
+	ProxyNodeBase
+}
+
+func (l LiteralNumber) MarshalJSON() ([]byte, error) {
+	proxy := ProxyLiteralNumber{}
+	proxy.Kind = "LiteralNumber"
+	proxy.OriginalString = l.OriginalString
+	proxy.ProxyNodeBase = NewProxyNodeBase(l.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (l *LiteralNumber) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLiteralNumber
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.OriginalString = proxy.OriginalString
+	l.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type LiteralString ast.LiteralString
+
+type ProxyLiteralString struct {
+	NodeKind        string `json:"__kind__"`
+	Value           string `json:"value"`
+	BlockIndent     string `json:"blockIndent"`
+	BlockTermIndent string `json:"blockTermIndent"`
+	ProxyNodeBase
+	Kind LiteralStringKind `json:"kind"`
+}
+
+func (l LiteralString) MarshalJSON() ([]byte, error) {
+	proxy := ProxyLiteralString{}
+	proxy.NodeKind = "LiteralString"
+	proxy.Value = l.Value
+	proxy.BlockIndent = l.BlockIndent
+	proxy.BlockTermIndent = l.BlockTermIndent
+	proxy.ProxyNodeBase = NewProxyNodeBase(l.NodeBase)
+	proxy.Kind = LiteralStringKind(l.Kind)
+	return json.Marshal(proxy)
+}
+
+func (l *LiteralString) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLiteralString
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.Value = proxy.Value
+	l.BlockIndent = proxy.BlockIndent
+	l.BlockTermIndent = proxy.BlockTermIndent
+	l.NodeBase = proxy.NodeBase()
+	l.Kind = ast.LiteralStringKind(proxy.Kind)
+	return nil
+}
+
+type Local ast.Local
+
+type ProxyLocal struct {
+	Kind  string            `json:"__kind__"`
+	Binds []json.RawMessage `json:"binds"`
+	Body  json.RawMessage   `json:"body"`
+	ProxyNodeBase
+}
+
+func (l Local) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyLocal{}
+	proxy.Kind = "Local"
+	proxy.Binds = make([]json.RawMessage, len(l.Binds))
+	for i, elem := range l.Binds {
+		proxy.Binds[i], err = marshalField(LocalBind(elem), fmt.Sprintf(".binds[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.Body, err = marshalField(NewNode(l.Body), ".body")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(l.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (l *Local) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLocal
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.Binds = make(ast.LocalBinds, len(proxy.Binds))
+	for i, elem := range proxy.Binds {
+		err = unmarshalField(elem, (*LocalBind)(&l.Binds[i]), fmt.Sprintf(".binds[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	l.Body, err = unmarshalNode(proxy.Body, ".body")
+	if err != nil {
+		return err
+	}
+	l.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type LocalBind ast.LocalBind
+
+type ProxyLocalBind struct {
+	Kind        string          `json:"__kind__"`
+	VarFodder   Fodder          `json:"varFodder"`
+	Body        json.RawMessage `json:"body"`
+	EqFodder    Fodder          `json:"eqFodder"`
+	Variable    ast.Identifier  `json:"variable"`
+	CloseFodder Fodder          `json:"closeFodder"`
+	Fun         json.RawMessage `json:"fun"`
+	LocRange    LocationRange   `json:"locRange"`
+}
+
+func (l LocalBind) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyLocalBind{}
+	proxy.Kind = "LocalBind"
+	proxy.VarFodder = NewFodder(l.VarFodder)
+	proxy.Body, err = marshalField(NewNode(l.Body), ".body")
+	if err != nil {
+		return nil, err
+	}
+	proxy.EqFodder = NewFodder(l.EqFodder)
+	proxy.Variable = l.Variable
+	proxy.CloseFodder = NewFodder(l.CloseFodder)
+	proxy.Fun, err = marshalField((*Function)(l.Fun), ".fun")
+	if err != nil {
+		return nil, err
+	}
+	proxy.LocRange = NewLocationRange(l.LocRange)
+	return json.Marshal(proxy)
+}
+
+func (l *LocalBind) UnmarshalJSON(data []byte) error {
+	var proxy ProxyLocalBind
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	l.VarFodder = proxy.VarFodder.Fodder()
+	l.Body, err = unmarshalNode(proxy.Body, ".body")
+	if err != nil {
+		return err
+	}
+	l.EqFodder = proxy.EqFodder.Fodder()
+	l.Variable = proxy.Variable
+	l.CloseFodder = proxy.CloseFodder.Fodder()
+	if !isNull(proxy.Fun) {
+		l.Fun = &ast.Function{}
+		err = unmarshalField(proxy.Fun, (*Function)(l.Fun), ".fun")
+		if err != nil {
+			return err
+		}
+	}
+	l.LocRange = proxy.LocRange.LocationRange()
+	return nil
+}
+
+type NamedArgument ast.NamedArgument
+
+type ProxyNamedArgument struct {
+	Kind        string          `json:"__kind__"`
+	NameFodder  Fodder          `json:"nameFodder"`
+	Name        ast.Identifier  `json:"name"`
+	EqFodder    Fodder          `json:"eqFodder"`
+	Arg         json.RawMessage `json:"arg"`
+	CommaFodder Fodder          `json:"commaFodder"`
+}
+
+func (n NamedArgument) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyNamedArgument{}
+	proxy.Kind = "NamedArgument"
+	proxy.NameFodder = NewFodder(n.NameFodder)
+	proxy.Name = n.Name
+	proxy.EqFodder = NewFodder(n.EqFodder)
+	proxy.Arg, err = marshalField(NewNode(n.Arg), ".arg")
+	if err != nil {
+		return nil, err
+	}
+	proxy.CommaFodder = NewFodder(n.CommaFodder)
+	return json.Marshal(proxy)
+}
+
+func (n *NamedArgument) UnmarshalJSON(data []byte) error {
+	var proxy ProxyNamedArgument
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	n.NameFodder = proxy.NameFodder.Fodder()
+	n.Name = proxy.Name
+	n.EqFodder = proxy.EqFodder.Fodder()
+	n.Arg, err = unmarshalNode(proxy.Arg, ".arg")
+	if err != nil {
+		return err
+	}
+	n.CommaFodder = proxy.CommaFodder.Fodder()
+	return nil
+}
+
+type Object ast.Object
+
+type ProxyObject struct {
+	Kind        string            `json:"__kind__"`
+	Fields      []json.RawMessage `json:"fields"`
+	CloseFodder Fodder            `json:"closeFodder"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+}
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyObject{}
+	proxy.Kind = "Object"
+	proxy.Fields = make([]json.RawMessage, len(o.Fields))
+	for i, elem := range o.Fields {
+		proxy.Fields[i], err = marshalField(ObjectField(elem), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.CloseFodder = NewFodder(o.CloseFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(o.NodeBase)
+	proxy.TrailingComma = o.TrailingComma
+	return json.Marshal(proxy)
+}
+
+func (o *Object) UnmarshalJSON(data []byte) error {
+	var proxy ProxyObject
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	o.Fields = make(ast.ObjectFields, len(proxy.Fields))
+	for i, elem := range proxy.Fields {
+		err = unmarshalField(elem, (*ObjectField)(&o.Fields[i]), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	o.CloseFodder = proxy.CloseFodder.Fodder()
+	o.NodeBase = proxy.NodeBase()
+	o.TrailingComma = proxy.TrailingComma
+	return nil
+}
+
+type ObjectComp ast.ObjectComp
+
+type ProxyObjectComp struct {
+	Kind                string            `json:"__kind__"`
+	Fields              []json.RawMessage `json:"fields"`
+	TrailingCommaFodder Fodder            `json:"trailingCommaFodder"`
+	CloseFodder         Fodder            `json:"closeFodder"`
+	Spec                json.RawMessage   `json:"spec"`
+	ProxyNodeBase
+	TrailingComma bool `json:"trailingComma"`
+}
+
+func (o ObjectComp) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyObjectComp{}
+	proxy.Kind = "ObjectComp"
+	proxy.Fields = make([]json.RawMessage, len(o.Fields))
+	for i, elem := range o.Fields {
+		proxy.Fields[i], err = marshalField(ObjectField(elem), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	proxy.TrailingCommaFodder = NewFodder(o.TrailingCommaFodder)
+	proxy.CloseFodder = NewFodder(o.CloseFodder)
+	proxy.Spec, err = marshalField(ForSpec(o.Spec), ".spec")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(o.NodeBase)
+	proxy.TrailingComma = o.TrailingComma
+	return json.Marshal(proxy)
+}
+
+func (o *ObjectComp) UnmarshalJSON(data []byte) error {
+	var proxy ProxyObjectComp
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	o.Fields = make(ast.ObjectFields, len(proxy.Fields))
+	for i, elem := range proxy.Fields {
+		err = unmarshalField(elem, (*ObjectField)(&o.Fields[i]), fmt.Sprintf(".fields[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+	o.TrailingCommaFodder = proxy.TrailingCommaFodder.Fodder()
+	o.CloseFodder = proxy.CloseFodder.Fodder()
+	err = unmarshalField(proxy.Spec, (*ForSpec)(&o.Spec), ".spec")
+	if err != nil {
+		return err
+	}
+	o.NodeBase = proxy.NodeBase()
+	o.TrailingComma = proxy.TrailingComma
+	return nil
+}
+
+type ObjectField ast.ObjectField
+
+type ProxyObjectField struct {
+	NodeKind         string           `json:"__kind__"`
+	Method           json.RawMessage  `json:"method"`
+	Id               *ast.Identifier  `json:"id"`
+	Fodder2          Fodder           `json:"fodder2"`
+	Fodder1          Fodder           `json:"fodder1"`
+	OpFodder         Fodder           `json:"opFodder"`
+	CommaFodder      Fodder           `json:"commaFodder"`
+	Expr1            json.RawMessage  `json:"expr1"`
+	Expr2            json.RawMessage  `json:"expr2"`
+	Expr3            json.RawMessage  `json:"expr3"`
+	LocRange         LocationRange    `json:"locRange"`
+	Kind             ObjectFieldKind  `json:"kind"`
+	Hide             ObjectFieldHide  `json:"hide"`
+	SuperSugar       bool             `json:"superSugar"`
+	LegacyHide       *ObjectFieldHide `json:"Hide,omitempty"`
+	LegacySuperSugar *bool            `json:"SuperSugar,omitempty"`
+}
+
+func (o ObjectField) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyObjectField{}
+	proxy.NodeKind = "ObjectField"
+	proxy.Method, err = marshalField((*Function)(o.Method), ".method")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Id = o.Id
+	proxy.Fodder2 = NewFodder(o.Fodder2)
+	proxy.Fodder1 = NewFodder(o.Fodder1)
+	proxy.OpFodder = NewFodder(o.OpFodder)
+	proxy.CommaFodder = NewFodder(o.CommaFodder)
+	proxy.Expr1, err = marshalField(NewNode(o.Expr1), ".expr1")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Expr2, err = marshalField(NewNode(o.Expr2), ".expr2")
+	if err != nil {
+		return nil, err
+	}
+	proxy.Expr3, err = marshalField(NewNode(o.Expr3), ".expr3")
+	if err != nil {
+		return nil, err
+	}
+	proxy.LocRange = NewLocationRange(o.LocRange)
+	proxy.Kind = ObjectFieldKind(o.Kind)
+	proxy.Hide = ObjectFieldHide(o.Hide)
+	proxy.SuperSugar = o.SuperSugar
+	return json.Marshal(proxy)
+}
+
+func (o *ObjectField) UnmarshalJSON(data []byte) error {
+	var proxy ProxyObjectField
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	if !isNull(proxy.Method) {
+		o.Method = &ast.Function{}
+		err = unmarshalField(proxy.Method, (*Function)(o.Method), ".method")
+		if err != nil {
+			return err
+		}
+	}
+	o.Id = proxy.Id
+	o.Fodder2 = proxy.Fodder2.Fodder()
+	o.Fodder1 = proxy.Fodder1.Fodder()
+	o.OpFodder = proxy.OpFodder.Fodder()
+	o.CommaFodder = proxy.CommaFodder.Fodder()
+	o.Expr1, err = unmarshalNode(proxy.Expr1, ".expr1")
+	if err != nil {
+		return err
+	}
+	o.Expr2, err = unmarshalNode(proxy.Expr2, ".expr2")
+	if err != nil {
+		return err
+	}
+	o.Expr3, err = unmarshalNode(proxy.Expr3, ".expr3")
+	if err != nil {
+		return err
+	}
+	o.LocRange = proxy.LocRange.LocationRange()
+	o.Kind = ast.ObjectFieldKind(proxy.Kind)
+	o.Hide = ast.ObjectFieldHide(proxy.Hide)
+	if proxy.LegacyHide != nil {
+		o.Hide = ast.ObjectFieldHide(*proxy.LegacyHide)
+	}
+	o.SuperSugar = proxy.SuperSugar
+	if proxy.LegacySuperSugar != nil {
+		o.SuperSugar = *proxy.LegacySuperSugar
+	}
+	return nil
+}
+
+type Parameter ast.Parameter
+
+type ProxyParameter struct {
+	Kind        string          `json:"__kind__"`
+	NameFodder  Fodder          `json:"nameFodder"`
+	Name        ast.Identifier  `json:"name"`
+	CommaFodder Fodder          `json:"commaFodder"`
+	EqFodder    Fodder          `json:"eqFodder"`
+	DefaultArg  json.RawMessage `json:"defaultArg"`
+	LocRange    LocationRange   `json:"locRange"`
+}
+
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyParameter{}
+	proxy.Kind = "Parameter"
+	proxy.NameFodder = NewFodder(p.NameFodder)
+	proxy.Name = p.Name
+	proxy.CommaFodder = NewFodder(p.CommaFodder)
+	proxy.EqFodder = NewFodder(p.EqFodder)
+	proxy.DefaultArg, err = marshalField(NewNode(p.DefaultArg), ".defaultArg")
+	if err != nil {
+		return nil, err
+	}
+	proxy.LocRange = NewLocationRange(p.LocRange)
+	return json.Marshal(proxy)
+}
+
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	var proxy ProxyParameter
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	p.NameFodder = proxy.NameFodder.Fodder()
+	p.Name = proxy.Name
+	p.CommaFodder = proxy.CommaFodder.Fodder()
+	p.EqFodder = proxy.EqFodder.Fodder()
+	p.DefaultArg, err = unmarshalNode(proxy.DefaultArg, ".defaultArg")
+	if err != nil {
+		return err
+	}
+	p.LocRange = proxy.LocRange.LocationRange()
+	return nil
+}
+
+type Parens ast.Parens
+
+type ProxyParens struct {
+	Kind        string          `json:"__kind__"`
+	Inner       json.RawMessage `json:"inner"`
+	CloseFodder Fodder          `json:"closeFodder"`
+	ProxyNodeBase
+}
+
+func (p Parens) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyParens{}
+	proxy.Kind = "Parens"
+	proxy.Inner, err = marshalField(NewNode(p.Inner), ".inner")
+	if err != nil {
+		return nil, err
+	}
+	proxy.CloseFodder = NewFodder(p.CloseFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(p.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (p *Parens) UnmarshalJSON(data []byte) error {
+	var proxy ProxyParens
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	p.Inner, err = unmarshalNode(proxy.Inner, ".inner")
+	if err != nil {
+		return err
+	}
+	p.CloseFodder = proxy.CloseFodder.Fodder()
+	p.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Self ast.Self
+
+type ProxySelf struct {
+	Kind string `json:"__kind__"`
+	ProxyNodeBase
+}
+
+func (s Self) MarshalJSON() ([]byte, error) {
+	proxy := ProxySelf{}
+	proxy.Kind = "Self"
+	proxy.ProxyNodeBase = NewProxyNodeBase(s.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (s *Self) UnmarshalJSON(data []byte) error {
+	var proxy ProxySelf
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	s.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Slice ast.Slice
+
+type ProxySlice struct {
+	Kind               string          `json:"__kind__"`
+	Target             json.RawMessage `json:"target"`
+	LeftBracketFodder  Fodder          `json:"leftBracketFodder"`
+	BeginIndex         json.RawMessage `json:"beginIndex"`
+	EndColonFodder     Fodder          `json:"endColonFodder"`
+	EndIndex           json.RawMessage `json:"endIndex"`
+	StepColonFodder    Fodder          `json:"stepColonFodder"`
+	Step               json.RawMessage `json:"step"`
+	RightBracketFodder Fodder          `json:"rightBracketFodder"`
+	ProxyNodeBase
+}
+
+func (s Slice) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxySlice{}
+	proxy.Kind = "Slice"
+	proxy.Target, err = marshalField(NewNode(s.Target), ".target")
+	if err != nil {
+		return nil, err
+	}
+	proxy.LeftBracketFodder = NewFodder(s.LeftBracketFodder)
+	proxy.BeginIndex, err = marshalField(NewNode(s.BeginIndex), ".beginIndex")
+	if err != nil {
+		return nil, err
+	}
+	proxy.EndColonFodder = NewFodder(s.EndColonFodder)
+	proxy.EndIndex, err = marshalField(NewNode(s.EndIndex), ".endIndex")
+	if err != nil {
+		return nil, err
+	}
+	proxy.StepColonFodder = NewFodder(s.StepColonFodder)
+	proxy.Step, err = marshalField(NewNode(s.Step), ".step")
+	if err != nil {
+		return nil, err
+	}
+	proxy.RightBracketFodder = NewFodder(s.RightBracketFodder)
+	proxy.ProxyNodeBase = NewProxyNodeBase(s.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (s *Slice) UnmarshalJSON(data []byte) error {
+	var proxy ProxySlice
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	s.Target, err = unmarshalNode(proxy.Target, ".target")
+	if err != nil {
+		return err
+	}
+	s.LeftBracketFodder = proxy.LeftBracketFodder.Fodder()
+	s.BeginIndex, err = unmarshalNode(proxy.BeginIndex, ".beginIndex")
+	if err != nil {
+		return err
+	}
+	s.EndColonFodder = proxy.EndColonFodder.Fodder()
+	s.EndIndex, err = unmarshalNode(proxy.EndIndex, ".endIndex")
+	if err != nil {
+		return err
+	}
+	s.StepColonFodder = proxy.StepColonFodder.Fodder()
+	s.Step, err = unmarshalNode(proxy.Step, ".step")
+	if err != nil {
+		return err
+	}
+	s.RightBracketFodder = proxy.RightBracketFodder.Fodder()
+	s.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type SuperIndex ast.SuperIndex
+
+type ProxySuperIndex struct {
+	Kind      string          `json:"__kind__"`
+	IDFodder  Fodder          `json:"idFodder"`
+	Index     json.RawMessage `json:"index"`
+	DotFodder Fodder          `json:"dotFodder"`
+	Id        *ast.Identifier `json:"id"`
+	ProxyNodeBase
+}
+
+func (s SuperIndex) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxySuperIndex{}
+	proxy.Kind = "SuperIndex"
+	proxy.IDFodder = NewFodder(s.IDFodder)
+	proxy.Index, err = marshalField(NewNode(s.Index), ".index")
+	if err != nil {
+		return nil, err
+	}
+	proxy.DotFodder = NewFodder(s.DotFodder)
+	proxy.Id = s.Id
+	proxy.ProxyNodeBase = NewProxyNodeBase(s.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (s *SuperIndex) UnmarshalJSON(data []byte) error {
+	var proxy ProxySuperIndex
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	s.IDFodder = proxy.IDFodder.Fodder()
+	s.Index, err = unmarshalNode(proxy.Index, ".index")
+	if err != nil {
+		return err
+	}
+	s.DotFodder = proxy.DotFodder.Fodder()
+	s.Id = proxy.Id
+	s.NodeBase = proxy.NodeBase()
+	return nil
+}
+
+type Unary ast.Unary
+
+type ProxyUnary struct {
+	Kind string          `json:"__kind__"`
+	Expr json.RawMessage `json:"expr"`
+	ProxyNodeBase
+	Op UnaryOp `json:"op"`
+}
+
+func (u Unary) MarshalJSON() ([]byte, error) {
+	var err error
+	proxy := ProxyUnary{}
+	proxy.Kind = "Unary"
+	proxy.Expr, err = marshalField(NewNode(u.Expr), ".expr")
+	if err != nil {
+		return nil, err
+	}
+	proxy.ProxyNodeBase = NewProxyNodeBase(u.NodeBase)
+	proxy.Op = UnaryOp(u.Op)
+	return json.Marshal(proxy)
+}
+
+func (u *Unary) UnmarshalJSON(data []byte) error {
+	var proxy ProxyUnary
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	u.Expr, err = unmarshalNode(proxy.Expr, ".expr")
+	if err != nil {
+		return err
+	}
+	u.NodeBase = proxy.NodeBase()
+	u.Op = ast.UnaryOp(proxy.Op)
+	return nil
+}
+
+type Var ast.Var
+
+type ProxyVar struct {
+	Kind string         `json:"__kind__"`
+	Id   ast.Identifier `json:"id"`
+	ProxyNodeBase
+}
+
+func (v Var) MarshalJSON() ([]byte, error) {
+	proxy := ProxyVar{}
+	proxy.Kind = "Var"
+	proxy.Id = v.Id
+	proxy.ProxyNodeBase = NewProxyNodeBase(v.NodeBase)
+	return json.Marshal(proxy)
+}
+
+func (v *Var) UnmarshalJSON(data []byte) error {
+	var proxy ProxyVar
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+	v.Id = proxy.Id
+	v.NodeBase = proxy.NodeBase()
+	return nil
+}
@@ -0,0 +1,167 @@
+package jsonnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/toolutils"
+)
+
+// canonicalize rewrites node in place so that code which only differs in how
+// it quotes strings, field names and indexes has the same AST. The values of
+// the strings end up unescaped, so the result is only fit for comparison.
+// Names turned into strings take the location of their field or index.
+func canonicalize(node ast.Node) {
+	switch n := node.(type) {
+	case nil:
+		return
+	case *ast.LiteralString:
+		canonicalizeString(n)
+	case *ast.Index:
+		if n.Id != nil {
+			n.Index = &ast.LiteralString{NodeBase: ast.NodeBase{LocRange: n.LocRange, Fodder: n.RightBracketFodder}, Value: string(*n.Id)}
+			n.Id = nil
+			n.RightBracketFodder = nil
+		}
+	case *ast.SuperIndex:
+		if n.Id != nil {
+			n.Index = &ast.LiteralString{NodeBase: ast.NodeBase{LocRange: n.LocRange, Fodder: n.IDFodder}, Value: string(*n.Id)}
+			n.Id = nil
+			n.IDFodder = nil
+		}
+	case *ast.Object:
+		canonicalizeFields(n.Fields)
+	case *ast.ObjectComp:
+		canonicalizeFields(n.Fields)
+	case *ast.Local:
+		for _, bind := range n.Binds {
+			if bind.Fun != nil {
+				canonicalize(bind.Fun)
+			}
+		}
+	}
+	for _, child := range toolutils.Children(node) {
+		canonicalize(child)
+	}
+}
+
+func canonicalizeFields(fields ast.ObjectFields) {
+	for i := range fields {
+		field := &fields[i]
+		if field.Kind != ast.ObjectFieldID || field.Id == nil {
+			continue
+		}
+		field.Kind = ast.ObjectFieldStr
+		field.Expr1 = &ast.LiteralString{NodeBase: ast.NodeBase{LocRange: field.LocRange, Fodder: field.Fodder1}, Value: string(*field.Id)}
+		field.Id = nil
+		field.Fodder1 = nil
+	}
+}
+
+func canonicalizeString(s *ast.LiteralString) {
+	if s.Kind == ast.StringSingle || s.Kind == ast.StringDouble {
+		if value, ok := unescapeString(s.Value); ok {
+			s.Value = value
+		}
+	}
+	s.Kind = ast.StringDouble
+	s.BlockIndent = ""
+	s.BlockTermIndent = ""
+}
+
+// unescapeString resolves the escape sequences of a single or double quoted
+// string.
+func unescapeString(s string) (string, bool) {
+	if !strings.Contains(s, `\`) {
+		return s, true
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", false
+		}
+		switch s[i] {
+		case '"', '\'', '\\', '/':
+			b.WriteByte(s[i])
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, ok := unescapeCodeUnit(s[i+1:])
+			if !ok {
+				return "", false
+			}
+			i += 4
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1:], `\u`) {
+				if low, ok := unescapeCodeUnit(s[i+3:]); ok {
+					if pair := utf16.DecodeRune(r, low); pair != utf8.RuneError {
+						r = pair
+						i += 6
+					}
+				}
+			}
+			b.WriteRune(r)
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// escapeString is the inverse of unescapeString for a string quoted with
+// quote, leaving everything that needs no escape untouched.
+func escapeString(s string, quote byte) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case quote, '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, c)
+				continue
+			}
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func unescapeCodeUnit(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s[:4], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return rune(n), true
+}
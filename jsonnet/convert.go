@@ -0,0 +1,474 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+	"gopkg.in/yaml.v3"
+)
+
+func JsonToJsonnet(text string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	node, err := jsonToNode(decoder)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	_, err = decoder.Token()
+	if err != io.EOF {
+		return "", fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+	return formatter.FormatNode(node, nil, formatter.DefaultOptions())
+}
+
+func jsonToken(decoder *json.Decoder) (json.Token, error) {
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return token, err
+}
+
+func jsonToNode(decoder *json.Decoder) (ast.Node, error) {
+	token, err := jsonToken(decoder)
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '[' {
+			return jsonToArray(decoder)
+		}
+		return jsonToObject(decoder)
+	case json.Number:
+		return numberNode(t.String()), nil
+	case string:
+		return stringNode(t), nil
+	case bool:
+		return &ast.LiteralBoolean{Value: t}, nil
+	default:
+		return &ast.LiteralNull{}, nil
+	}
+}
+
+func jsonToArray(decoder *json.Decoder) (ast.Node, error) {
+	array := &ast.Array{}
+	for decoder.More() {
+		elem, err := jsonToNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+		*elem.OpenFodder() = lineEnd()
+		array.Elements = append(array.Elements, ast.CommaSeparatedExpr{Expr: elem})
+	}
+	_, err := jsonToken(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if len(array.Elements) > 0 {
+		array.CloseFodder = lineEnd()
+		array.TrailingComma = true
+	}
+	return array, nil
+}
+
+func jsonToObject(decoder *json.Decoder) (ast.Node, error) {
+	object := &ast.Object{}
+	keys := make(map[string]bool)
+	for decoder.More() {
+		token, err := jsonToken(decoder)
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		if keys[key] {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		keys[key] = true
+		value, err := jsonToNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+		field := objectField(key, value)
+		field.Fodder1 = lineEnd()
+		object.Fields = append(object.Fields, field)
+	}
+	_, err := jsonToken(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if len(object.Fields) > 0 {
+		object.CloseFodder = lineEnd()
+		object.TrailingComma = true
+	}
+	return object, nil
+}
+
+func YamlToJsonnet(text string) (string, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(text))
+	var docs []*yaml.Node
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid YAML: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+	c := yamlConverter{path: make(map[*yaml.Node]bool)}
+	var node ast.Node
+	var final ast.Fodder
+	switch len(docs) {
+	case 0:
+		node = &ast.LiteralNull{}
+	case 1:
+		doc := docs[0]
+		content, line, foot, err := c.yamlDocument(doc)
+		if err != nil {
+			return "", err
+		}
+		node = content
+		*node.OpenFodder() = append(yamlDocumentHead(doc), *node.OpenFodder()...)
+		if line != "" || foot != "" {
+			final = leadingFodder(line, foot)
+		}
+	default:
+		array := &ast.Array{TrailingComma: true}
+		var line, foot string
+		for _, doc := range docs {
+			content, contentLine, contentFoot, err := c.yamlDocument(doc)
+			if err != nil {
+				return "", err
+			}
+			*content.OpenFodder() = append(append(leadingFodder(line, foot), yamlDocumentHead(doc)...), *content.OpenFodder()...)
+			array.Elements = append(array.Elements, ast.CommaSeparatedExpr{Expr: content})
+			line, foot = contentLine, contentFoot
+		}
+		array.CloseFodder = leadingFodder(line, foot)
+		node = array
+	}
+	return formatter.FormatNode(node, final, formatter.DefaultOptions())
+}
+
+// yamlDocument converts the content of a document and returns the line and
+// foot comments that still have to be placed after it.
+func (c *yamlConverter) yamlDocument(doc *yaml.Node) (ast.Node, string, string, error) {
+	if len(doc.Content) == 0 {
+		return &ast.LiteralNull{}, "", doc.FootComment, nil
+	}
+	content := doc.Content[0]
+	foot := joinBlocks(content.FootComment, doc.FootComment)
+	if isYamlContainer(content) {
+		node, err := c.yamlToNode(content, content.LineComment)
+		return node, "", foot, err
+	}
+	node, err := c.yamlToNode(content, "")
+	return node, content.LineComment, foot, err
+}
+
+// yamlDocumentHead keeps the document comment separated from the comment
+// of its content by a blank line, as YAML requires.
+func yamlDocumentHead(doc *yaml.Node) ast.Fodder {
+	fodder := comments(doc.HeadComment)
+	if len(fodder) > 0 {
+		fodder[len(fodder)-1].Blanks = 1
+	}
+	if len(doc.Content) > 0 {
+		fodder = append(fodder, comments(doc.Content[0].HeadComment)...)
+	}
+	return fodder
+}
+
+func isYamlContainer(node *yaml.Node) bool {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && len(node.Content) > 0
+}
+
+// maxYamlExpandedNodes caps the nodes that aliases and merge keys can expand
+// to, which guards against documents like the billion laughs.
+const maxYamlExpandedNodes = 100000
+
+// yamlConverter tracks the containers being converted, to detect aliases
+// that refer to one of them, and the nodes produced by expanding aliases.
+type yamlConverter struct {
+	path     map[*yaml.Node]bool
+	aliases  int
+	expanded int
+}
+
+// yamlToNode converts a YAML node. The line comment is placed after the
+// opening bracket of a non-empty container.
+func (c *yamlConverter) yamlToNode(node *yaml.Node, line string) (ast.Node, error) {
+	if c.aliases > 0 {
+		c.expanded++
+		if c.expanded > maxYamlExpandedNodes {
+			return nil, fmt.Errorf("line %d: aliases expand to more than %d nodes", node.Line, maxYamlExpandedNodes)
+		}
+	}
+	switch node.Kind {
+	case yaml.AliasNode:
+		if c.path[node.Alias] {
+			return nil, fmt.Errorf("recursive alias at line %d", node.Line)
+		}
+		c.aliases++
+		defer func() { c.aliases-- }()
+		return c.yamlToNode(node.Alias, line)
+	case yaml.MappingNode:
+		c.path[node] = true
+		defer delete(c.path, node)
+		return c.yamlToObject(node, line)
+	case yaml.SequenceNode:
+		c.path[node] = true
+		defer delete(c.path, node)
+		return c.yamlToArray(node, line)
+	case yaml.ScalarNode:
+		return yamlToScalar(node)
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+}
+
+func (c *yamlConverter) yamlToArray(node *yaml.Node, line string) (ast.Node, error) {
+	array := &ast.Array{}
+	var foot string
+	for _, item := range node.Content {
+		fodder := leadingFodder(line, foot, item.HeadComment)
+		line, foot = "", item.FootComment
+		if isYamlContainer(item) {
+			elem, err := c.yamlToNode(item, item.LineComment)
+			if err != nil {
+				return nil, err
+			}
+			*elem.OpenFodder() = fodder
+			array.Elements = append(array.Elements, ast.CommaSeparatedExpr{Expr: elem})
+			continue
+		}
+		elem, err := c.yamlToNode(item, "")
+		if err != nil {
+			return nil, err
+		}
+		*elem.OpenFodder() = fodder
+		array.Elements = append(array.Elements, ast.CommaSeparatedExpr{Expr: elem})
+		line = item.LineComment
+	}
+	if len(array.Elements) > 0 {
+		array.CloseFodder = leadingFodder(line, foot)
+		array.TrailingComma = true
+	}
+	return array, nil
+}
+
+type yamlEntry struct {
+	key    string
+	value  *yaml.Node
+	keyRef *yaml.Node
+}
+
+func (c *yamlConverter) yamlToObject(node *yaml.Node, line string) (ast.Node, error) {
+	entries, err := c.yamlEntries(node)
+	if err != nil {
+		return nil, err
+	}
+	object := &ast.Object{}
+	var foot string
+	for _, entry := range entries {
+		key, value := entry.keyRef, entry.value
+		var heads []string
+		if key != nil {
+			heads = []string{key.HeadComment, value.HeadComment}
+		}
+		fodder := leadingFodder(line, append([]string{foot}, heads...)...)
+		line, foot = "", ""
+		if key != nil {
+			line = joinComments(key.LineComment, value.LineComment)
+			foot = joinComments(key.FootComment, value.FootComment)
+		}
+		if key == nil {
+			c.aliases++
+		}
+		var expr ast.Node
+		if isYamlContainer(value) {
+			expr, err = c.yamlToNode(value, line)
+			line = ""
+		} else {
+			expr, err = c.yamlToNode(value, "")
+		}
+		if key == nil {
+			c.aliases--
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := objectField(entry.key, expr)
+		field.Fodder1 = fodder
+		object.Fields = append(object.Fields, field)
+	}
+	if len(object.Fields) > 0 {
+		object.CloseFodder = leadingFodder(line, foot)
+		object.TrailingComma = true
+	}
+	return object, nil
+}
+
+// yamlEntries lists the entries of a mapping with merge keys expanded.
+// Entries that are merged in do not carry comments.
+func (c *yamlConverter) yamlEntries(node *yaml.Node) ([]yamlEntry, error) {
+	explicit := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+		}
+		if key.ShortTag() == "!!merge" {
+			continue
+		}
+		if explicit[key.Value] {
+			return nil, fmt.Errorf("line %d: duplicate key %q", key.Line, key.Value)
+		}
+		explicit[key.Value] = true
+	}
+	var entries []yamlEntry
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() != "!!merge" {
+			entries = append(entries, yamlEntry{key: key.Value, value: value, keyRef: key})
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			for source.Kind == yaml.AliasNode {
+				if c.path[source.Alias] {
+					return nil, fmt.Errorf("recursive alias at line %d", source.Line)
+				}
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: merge value must be a mapping", source.Line)
+			}
+			c.path[source] = true
+			merged, err := c.yamlEntries(source)
+			delete(c.path, source)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range merged {
+				if explicit[entry.key] || seen[entry.key] {
+					continue
+				}
+				seen[entry.key] = true
+				entries = append(entries, yamlEntry{key: entry.key, value: entry.value})
+			}
+		}
+	}
+	return entries, nil
+}
+
+func yamlToScalar(node *yaml.Node) (ast.Node, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return &ast.LiteralNull{}, nil
+	case "!!bool":
+		var v bool
+		err := node.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.LiteralBoolean{Value: v}, nil
+	case "!!int", "!!float":
+		var v any
+		err := node.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+		switch n := v.(type) {
+		case float64:
+			if math.IsInf(n, 0) || math.IsNaN(n) {
+				return nil, fmt.Errorf("line %d: %s cannot be represented in Jsonnet", node.Line, node.Value)
+			}
+			return number(n)
+		default:
+			return numberNode(fmt.Sprint(n)), nil
+		}
+	default:
+		if node.Style&yaml.LiteralStyle != 0 && isTextBlock(node.Value) {
+			return &ast.LiteralString{Value: node.Value, Kind: ast.StringBlock, BlockIndent: "  "}, nil
+		}
+		return stringNode(node.Value), nil
+	}
+}
+
+// isTextBlock reports whether value reads back the same when written as a
+// text block: it has to span lines, the first line that is not blank must not
+// start with whitespace that would be taken as indentation, and carriage
+// returns would be dropped.
+func isTextBlock(value string) bool {
+	first := strings.TrimLeft(value, "\n")
+	return strings.Contains(value, "\n") && first != "" && first[0] != ' ' && first[0] != '\t' && !strings.Contains(value, "\r")
+}
+
+// leadingFodder starts a new line, ending the previous one with the given
+// line comment, and puts the comment blocks on their own lines.
+func leadingFodder(line string, blocks ...string) ast.Fodder {
+	fodder := lineEnd()
+	if line != "" {
+		fodder[0].Comment = []string{line}
+	}
+	for _, block := range blocks {
+		fodder = append(fodder, comments(block)...)
+	}
+	return fodder
+}
+
+// comments converts YAML comment blocks to paragraphs. Blank lines between
+// comments are kept.
+func comments(blocks ...string) ast.Fodder {
+	var fodder ast.Fodder
+	for _, block := range blocks {
+		if block == "" {
+			continue
+		}
+		for _, line := range strings.Split(block, "\n") {
+			if line == "" {
+				if len(fodder) > 0 {
+					fodder[len(fodder)-1].Blanks++
+				}
+				continue
+			}
+			fodder = append(fodder, ast.FodderElement{Kind: ast.FodderParagraph, Comment: []string{line}})
+		}
+	}
+	return fodder
+}
+
+func joinBlocks(a string, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "\n" + b
+}
+
+func joinComments(a string, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + " " + b
+	}
+}
@@ -0,0 +1,119 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesugar(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonnet  string
+		expected string
+	}{
+		{
+			name:     "object",
+			jsonnet:  "{ a: 1 }",
+			expected: "DesugaredObject",
+		},
+		{
+			name:     "object comprehension",
+			jsonnet:  "{ [a]: 1 for a in ['a'] }",
+			expected: "Apply",
+		},
+		{
+			name:     "array comprehension",
+			jsonnet:  "[a for a in [1]]",
+			expected: "Apply",
+		},
+		{
+			name:     "parens",
+			jsonnet:  "(1)",
+			expected: "LiteralNumber",
+		},
+		{
+			name:     "dollar",
+			jsonnet:  "{ a: $ }",
+			expected: "DesugaredObject",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Desugar(DefaultFilename, tt.jsonnet, DefaultParseOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res.(map[string]any)["__kind__"])
+
+			b, err := json.Marshal(res)
+			require.NoError(t, err)
+			node, err := UnmarshalNode(b)
+			require.NoError(t, err)
+			roundTrip, err := MarshalNode(node)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(b), string(roundTrip))
+		})
+	}
+}
+
+func TestDesugarObject(t *testing.T) {
+	res, err := Desugar(DefaultFilename, "{ local b = 2, a:: b, c+: 1, assert true }", ParseOptions{Compact: true})
+	require.NoError(t, err)
+
+	object := res.(map[string]any)
+	assert.Len(t, object["asserts"], 1)
+	assert.Len(t, object["locals"], 2)
+
+	fields := object["fields"].([]any)
+	require.Len(t, fields, 2)
+	a := fields[0].(map[string]any)
+	assert.Equal(t, "DesugaredObjectField", a["__kind__"])
+	assert.Equal(t, "a", a["name"].(map[string]any)["value"])
+	assert.Equal(t, "hidden", a["hide"])
+	assert.Equal(t, false, a["plusSuper"])
+	c := fields[1].(map[string]any)
+	assert.Equal(t, "c", c["name"].(map[string]any)["value"])
+	assert.Equal(t, "inherit", c["hide"])
+	assert.Equal(t, true, c["plusSuper"])
+}
+
+func TestDesugarJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "jsonnet must be provided",
+		},
+		{
+			name:     "non-string argument",
+			input:    []any{123},
+			expected: "jsonnet must be a string",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"{ a: }", nil, "lib/config.libsonnet"},
+			expected: "lib/config.libsonnet:1:6-7",
+		},
+		{
+			name:     "static error",
+			input:    []any{"a"},
+			expected: "Unknown variable: a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DesugarJsonnet().Func(tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
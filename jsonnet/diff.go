@@ -0,0 +1,213 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type EditType string
+
+const (
+	EditInserted EditType = "inserted"
+	EditRemoved  EditType = "removed"
+	EditChanged  EditType = "changed"
+)
+
+type Edit struct {
+	Type       EditType  `json:"type"`
+	Attributes []string  `json:"attributes,omitempty"`
+	A          *EditSide `json:"a"`
+	B          *EditSide `json:"b"`
+}
+
+type EditSide struct {
+	Path     string `json:"path"`
+	LocRange any    `json:"locRange"`
+	Node     any    `json:"node,omitempty"`
+}
+
+func Diff(a string, b string) (any, error) {
+	nodeA, err := canonicalValue(a, "a")
+	if err != nil {
+		return nil, err
+	}
+	nodeB, err := canonicalValue(b, "b")
+	if err != nil {
+		return nil, err
+	}
+	d := differ{edits: make([]Edit, 0)}
+	rootA := nodeA.(map[string]any)
+	rootB := nodeB.(map[string]any)
+	d.diff(diffPos{path: "$", value: rootA, owner: rootA}, diffPos{path: "$", value: rootB, owner: rootB})
+	bytes, err := json.Marshal(d.edits)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0)
+	err = json.Unmarshal(bytes, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type diffPos struct {
+	path  string
+	value any
+	owner map[string]any
+}
+
+func (p diffPos) child(key string, value any) diffPos {
+	res := diffPos{path: p.path + "." + key, value: value, owner: p.owner}
+	if isNode(value) {
+		res.owner = value.(map[string]any)
+	}
+	return res
+}
+
+func (p diffPos) elem(i int, value any) diffPos {
+	res := diffPos{path: fmt.Sprintf("%s[%d]", p.path, i), value: value, owner: p.owner}
+	if isNode(value) {
+		res.owner = value.(map[string]any)
+	}
+	return res
+}
+
+func (p diffPos) side(withNode bool) *EditSide {
+	side := &EditSide{Path: p.path, LocRange: nodeLocation(p.owner)}
+	if withNode {
+		side.Node = p.value
+	}
+	return side
+}
+
+func isNode(value any) bool {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = m["__kind__"]
+	return ok
+}
+
+type differ struct {
+	edits []Edit
+}
+
+func (d *differ) diff(a diffPos, b diffPos) {
+	switch {
+	case a.value == nil && b.value == nil:
+	case a.value == nil:
+		d.edits = append(d.edits, Edit{Type: EditInserted, A: a.side(false), B: b.side(true)})
+	case b.value == nil:
+		d.edits = append(d.edits, Edit{Type: EditRemoved, A: a.side(true), B: b.side(false)})
+	default:
+		switch va := a.value.(type) {
+		case map[string]any:
+			vb, ok := b.value.(map[string]any)
+			if !ok || va["__kind__"] != vb["__kind__"] {
+				d.edits = append(d.edits, Edit{Type: EditChanged, A: a.side(true), B: b.side(true)})
+				return
+			}
+			d.diffMap(a, va, b, vb)
+		case []any:
+			vb, ok := b.value.([]any)
+			if !ok {
+				d.edits = append(d.edits, Edit{Type: EditChanged, A: a.side(true), B: b.side(true)})
+				return
+			}
+			d.diffArray(a, va, b, vb)
+		default:
+			if a.value != b.value {
+				d.edits = append(d.edits, Edit{Type: EditChanged, A: a.side(true), B: b.side(true)})
+			}
+		}
+	}
+}
+
+func (d *differ) diffMap(a diffPos, va map[string]any, b diffPos, vb map[string]any) {
+	keySet := make(map[string]bool)
+	for key := range va {
+		keySet[key] = true
+	}
+	for key := range vb {
+		keySet[key] = true
+	}
+	var keys []string
+	for key := range keySet {
+		if !ignoredKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var attributes []string
+	var structural []string
+	for _, key := range keys {
+		if isScalar(va[key]) && isScalar(vb[key]) {
+			if va[key] != vb[key] {
+				attributes = append(attributes, key)
+			}
+			continue
+		}
+		structural = append(structural, key)
+	}
+	if len(attributes) > 0 {
+		d.edits = append(d.edits, Edit{Type: EditChanged, Attributes: attributes, A: a.side(true), B: b.side(true)})
+	}
+	for _, key := range structural {
+		d.diff(a.child(key, va[key]), b.child(key, vb[key]))
+	}
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+func (d *differ) diffArray(a diffPos, va []any, b diffPos, vb []any) {
+	lengths := make([][]int, len(va)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(vb)+1)
+	}
+	for i := len(va) - 1; i >= 0; i-- {
+		for j := len(vb) - 1; j >= 0; j-- {
+			if structurallyEqual(va[i], vb[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	gapA, gapB := 0, 0
+	flush := func() {
+		for ; gapA < i && gapB < j; gapA, gapB = gapA+1, gapB+1 {
+			d.diff(a.elem(gapA, va[gapA]), b.elem(gapB, vb[gapB]))
+		}
+		for ; gapA < i; gapA++ {
+			d.edits = append(d.edits, Edit{Type: EditRemoved, A: a.elem(gapA, va[gapA]).side(true), B: b.side(false)})
+		}
+		for ; gapB < j; gapB++ {
+			d.edits = append(d.edits, Edit{Type: EditInserted, A: a.side(false), B: b.elem(gapB, vb[gapB]).side(true)})
+		}
+	}
+	for i < len(va) && j < len(vb) {
+		switch {
+		case structurallyEqual(va[i], vb[j]):
+			flush()
+			i, j = i+1, j+1
+			gapA, gapB = i, j
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	i, j = len(va), len(vb)
+	flush()
+}
@@ -0,0 +1,32 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func DiffJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "diffJsonnet",
+		Params: ast.Identifiers{"a", "b"},
+		Func: func(input []any) (any, error) {
+			if len(input) != 2 {
+				return nil, fmt.Errorf("a and b must be provided")
+			}
+			a, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("a must be a string")
+			}
+			b, ok := input[1].(string)
+			if !ok {
+				return nil, fmt.Errorf("b must be a string")
+			}
+			out, err := Diff(a, b)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package jsonnet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected []string
+	}{
+		{
+			name:     "formatting only",
+			a:        "{a: 1, b: [1, 2,]}",
+			b:        "{\n  // comment\n  a: 1,\n\n  b: [\n    1,\n    2,\n  ],\n}\n",
+			expected: []string{},
+		},
+		{
+			name:     "quoting only",
+			a:        "{ a: 'x', 'b-c': \"it's\", d: e.f, g: |||\n  h\n||| }",
+			b:        "{ 'a': \"x\", \"b-c\": 'it\\'s', d: e['f'], g: 'h\\n' }",
+			expected: []string{},
+		},
+		{
+			name:     "changed field name",
+			a:        "{ a: 1 }",
+			b:        "{ 'b': 1 }",
+			expected: []string{"changed [value] $.fields[0].expr1 1:3 $.fields[0].expr1 1:3"},
+		},
+		{
+			name:     "changed attribute",
+			a:        "{ a: 'foo' }",
+			b:        "{ a: 'bar' }",
+			expected: []string{"changed [value] $.fields[0].expr2 1:6 $.fields[0].expr2 1:6"},
+		},
+		{
+			name:     "changed kind",
+			a:        "local a = 1; a",
+			b:        "local a = 1; a + 1",
+			expected: []string{"changed [] $.body 1:14 $.body 1:14"},
+		},
+		{
+			name:     "inserted element",
+			a:        "[1, 3]",
+			b:        "[1, 2, 3]",
+			expected: []string{"inserted [] $.elements 1:1 $.elements[1] 1:5"},
+		},
+		{
+			name:     "removed field",
+			a:        "{\n  a: 1,\n  b: 2,\n}",
+			b:        "{ a: 1 }",
+			expected: []string{"removed [] $.fields[1] 3:3 $.fields 1:1"},
+		},
+		{
+			name:     "replaced element",
+			a:        "[1, f(x), 3]",
+			b:        "[1, f(y), 3]",
+			expected: []string{"changed [id] $.elements[1].expr.arguments.positional[0].expr 1:7 $.elements[1].expr.arguments.positional[0].expr 1:7"},
+		},
+		{
+			name:     "optional child",
+			a:        "if a then b",
+			b:        "if a then b else c",
+			expected: []string{"inserted [] $.branchFalse 1:1 $.branchFalse 1:18"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DiffJsonnet().Func([]any{tt.a, tt.b})
+			require.NoError(t, err)
+
+			edits := []string{}
+			for _, elem := range result.([]any) {
+				e := elem.(map[string]any)
+				attributes, _ := e["attributes"].([]any)
+				a := e["a"].(map[string]any)
+				b := e["b"].(map[string]any)
+				edits = append(edits, fmt.Sprintf("%s %v %s %s %s %s", e["type"], attributes, a["path"], beginOf(a), b["path"], beginOf(b)))
+			}
+			assert.Equal(t, tt.expected, edits)
+		})
+	}
+}
+
+func TestDiffJsonnetSides(t *testing.T) {
+	result, err := DiffJsonnet().Func([]any{"[1]", "[1, 2]"})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	edit := result.([]any)[0].(map[string]any)
+	a := edit["a"].(map[string]any)
+	b := edit["b"].(map[string]any)
+	assert.Nil(t, a["node"])
+	assert.Equal(t, "a.jsonnet", a["locRange"].(map[string]any)["fileName"])
+	assert.Equal(t, "CommaSeparatedExpr", b["node"].(map[string]any)["__kind__"])
+	assert.Equal(t, "b.jsonnet", b["locRange"].(map[string]any)["fileName"])
+}
+
+func TestDiffJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "a and b must be provided",
+		},
+		{
+			name:     "non-string a",
+			input:    []any{1, "null"},
+			expected: "a must be a string",
+		},
+		{
+			name:     "non-string b",
+			input:    []any{"null", 1},
+			expected: "b must be a string",
+		},
+		{
+			name:     "parse error in a",
+			input:    []any{"{", "null"},
+			expected: "a.jsonnet:1:2",
+		},
+		{
+			name:     "parse error in b",
+			input:    []any{"null", "{"},
+			expected: "b.jsonnet:1:2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DiffJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+// The AST enums are marshalled by name. Unmarshalling also accepts the
+// numeric value of the go-jsonnet constant for backwards compatibility.
+
+type BinaryOp ast.BinaryOp
+
+var binaryOps = map[string]ast.BinaryOp{
+	"*":  ast.BopMult,
+	"/":  ast.BopDiv,
+	"%":  ast.BopPercent,
+	"+":  ast.BopPlus,
+	"-":  ast.BopMinus,
+	"<<": ast.BopShiftL,
+	">>": ast.BopShiftR,
+	">":  ast.BopGreater,
+	">=": ast.BopGreaterEq,
+	"<":  ast.BopLess,
+	"<=": ast.BopLessEq,
+	"in": ast.BopIn,
+	"==": ast.BopManifestEqual,
+	"!=": ast.BopManifestUnequal,
+	"&":  ast.BopBitwiseAnd,
+	"^":  ast.BopBitwiseXor,
+	"|":  ast.BopBitwiseOr,
+	"&&": ast.BopAnd,
+	"||": ast.BopOr,
+}
+
+func (b BinaryOp) MarshalJSON() ([]byte, error) {
+	return marshalEnum("binary operator", binaryOps, ast.BinaryOp(b))
+}
+
+func (b *BinaryOp) UnmarshalJSON(data []byte) error {
+	op, err := unmarshalEnum("binary operator", binaryOps, data)
+	if err != nil {
+		return err
+	}
+	*b = BinaryOp(op)
+	return nil
+}
+
+type UnaryOp ast.UnaryOp
+
+var unaryOps = map[string]ast.UnaryOp{
+	"!": ast.UopNot,
+	"~": ast.UopBitwiseNot,
+	"+": ast.UopPlus,
+	"-": ast.UopMinus,
+}
+
+func (u UnaryOp) MarshalJSON() ([]byte, error) {
+	return marshalEnum("unary operator", unaryOps, ast.UnaryOp(u))
+}
+
+func (u *UnaryOp) UnmarshalJSON(data []byte) error {
+	op, err := unmarshalEnum("unary operator", unaryOps, data)
+	if err != nil {
+		return err
+	}
+	*u = UnaryOp(op)
+	return nil
+}
+
+type ObjectFieldKind ast.ObjectFieldKind
+
+var objectFieldKinds = map[string]ast.ObjectFieldKind{
+	"assert":    ast.ObjectAssert,
+	"fieldId":   ast.ObjectFieldID,
+	"fieldExpr": ast.ObjectFieldExpr,
+	"fieldStr":  ast.ObjectFieldStr,
+	"local":     ast.ObjectLocal,
+}
+
+func (k ObjectFieldKind) MarshalJSON() ([]byte, error) {
+	return marshalEnum("object field kind", objectFieldKinds, ast.ObjectFieldKind(k))
+}
+
+func (k *ObjectFieldKind) UnmarshalJSON(data []byte) error {
+	kind, err := unmarshalEnum("object field kind", objectFieldKinds, data)
+	if err != nil {
+		return err
+	}
+	*k = ObjectFieldKind(kind)
+	return nil
+}
+
+type ObjectFieldHide ast.ObjectFieldHide
+
+var objectFieldHides = map[string]ast.ObjectFieldHide{
+	"hidden":  ast.ObjectFieldHidden,
+	"inherit": ast.ObjectFieldInherit,
+	"visible": ast.ObjectFieldVisible,
+}
+
+func (h ObjectFieldHide) MarshalJSON() ([]byte, error) {
+	return marshalEnum("object field hide", objectFieldHides, ast.ObjectFieldHide(h))
+}
+
+func (h *ObjectFieldHide) UnmarshalJSON(data []byte) error {
+	hide, err := unmarshalEnum("object field hide", objectFieldHides, data)
+	if err != nil {
+		return err
+	}
+	*h = ObjectFieldHide(hide)
+	return nil
+}
+
+type FodderKind ast.FodderKind
+
+var fodderKinds = map[string]ast.FodderKind{
+	"lineEnd":      ast.FodderLineEnd,
+	"interstitial": ast.FodderInterstitial,
+	"paragraph":    ast.FodderParagraph,
+}
+
+func (k FodderKind) MarshalJSON() ([]byte, error) {
+	return marshalEnum("fodder kind", fodderKinds, ast.FodderKind(k))
+}
+
+func (k *FodderKind) UnmarshalJSON(data []byte) error {
+	kind, err := unmarshalEnum("fodder kind", fodderKinds, data)
+	if err != nil {
+		return err
+	}
+	*k = FodderKind(kind)
+	return nil
+}
+
+type LiteralStringKind ast.LiteralStringKind
+
+var literalStringKinds = map[string]ast.LiteralStringKind{
+	"single":         ast.StringSingle,
+	"double":         ast.StringDouble,
+	"block":          ast.StringBlock,
+	"verbatimDouble": ast.VerbatimStringDouble,
+	"verbatimSingle": ast.VerbatimStringSingle,
+}
+
+func (k LiteralStringKind) MarshalJSON() ([]byte, error) {
+	return marshalEnum("string kind", literalStringKinds, ast.LiteralStringKind(k))
+}
+
+func (k *LiteralStringKind) UnmarshalJSON(data []byte) error {
+	kind, err := unmarshalEnum("string kind", literalStringKinds, data)
+	if err != nil {
+		return err
+	}
+	*k = LiteralStringKind(kind)
+	return nil
+}
+
+func marshalEnum[T ~int](what string, names map[string]T, value T) ([]byte, error) {
+	for name, v := range names {
+		if v == value {
+			return json.Marshal(name)
+		}
+	}
+	return nil, fmt.Errorf("unknown %s: %d", what, value)
+}
+
+func unmarshalEnum[T ~int](what string, names map[string]T, data []byte) (T, error) {
+	if string(data) == "null" {
+		return 0, nil
+	}
+	var name string
+	if json.Unmarshal(data, &name) == nil {
+		value, ok := names[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown %s: %s", what, name)
+		}
+		return value, nil
+	}
+	var number int
+	err := json.Unmarshal(data, &number)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a string or a number", what)
+	}
+	for _, value := range names {
+		if value == T(number) {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s: %d", what, number)
+}
@@ -0,0 +1,120 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumNames(t *testing.T) {
+	res, err := Parse(DefaultFilename, "{ a:: -1 + 2, 'b': |||\n  c\n|||, local d = 1, assert true }", ParseOptions{Compact: true})
+	require.NoError(t, err)
+
+	fields := res.(map[string]any)["fields"].([]any)
+	require.Len(t, fields, 4)
+	a := fields[0].(map[string]any)
+	assert.Equal(t, "fieldId", a["kind"])
+	assert.Equal(t, "hidden", a["hide"])
+	binary := a["expr2"].(map[string]any)
+	assert.Equal(t, "+", binary["op"])
+	assert.Equal(t, "-", binary["left"].(map[string]any)["op"])
+	b := fields[1].(map[string]any)
+	assert.Equal(t, "fieldStr", b["kind"])
+	assert.Equal(t, "inherit", b["hide"])
+	assert.Equal(t, "single", b["expr1"].(map[string]any)["kind"])
+	assert.Equal(t, "block", b["expr2"].(map[string]any)["kind"])
+	assert.Equal(t, "local", fields[2].(map[string]any)["kind"])
+	assert.Equal(t, "assert", fields[3].(map[string]any)["kind"])
+}
+
+func TestEnumNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "binary",
+			input:    `{"__kind__": "Binary", "op": 0, "left": {"__kind__": "Var", "id": "a"}, "right": {"__kind__": "Var", "id": "b"}}`,
+			expected: "a * b",
+		},
+		{
+			name:     "unary",
+			input:    `{"__kind__": "Unary", "op": 1, "expr": {"__kind__": "Var", "id": "a"}}`,
+			expected: "~a",
+		},
+		{
+			name:     "object field",
+			input:    `{"__kind__": "Object", "fields": [{"__kind__": "ObjectField", "kind": 1, "hide": 2, "id": "a", "expr2": {"__kind__": "LiteralString", "value": "b", "kind": 1}}]}`,
+			expected: "{ a::: 'b' }",
+		},
+		{
+			name:     "fodder",
+			input:    `{"__kind__": "Var", "id": "a", "fodder": [{"kind": 2, "comment": ["// a"], "blanks": 0, "indent": 0}]}`,
+			expected: "// a\na",
+		},
+		{
+			name:     "mixed",
+			input:    `{"__kind__": "Binary", "op": "-", "left": {"__kind__": "Unary", "op": 0, "expr": {"__kind__": "Var", "id": "a"}}, "right": {"__kind__": "Var", "id": "b"}}`,
+			expected: "!a - b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := UnmarshalNode([]byte(tt.input))
+			require.NoError(t, err)
+			res, err := formatter.FormatNode(node, nil, formatter.DefaultOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected+"\n", res)
+		})
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "unknown binary operator",
+			input:    `{"__kind__": "Binary", "op": "**"}`,
+			expected: "unknown binary operator: **",
+		},
+		{
+			name:     "binary operator out of range",
+			input:    `{"__kind__": "Binary", "op": 19}`,
+			expected: "unknown binary operator: 19",
+		},
+		{
+			name:     "unknown unary operator",
+			input:    `{"__kind__": "Unary", "op": "?"}`,
+			expected: "unknown unary operator: ?",
+		},
+		{
+			name:     "unknown object field hide",
+			input:    `{"__kind__": "Object", "fields": [{"__kind__": "ObjectField", "kind": "fieldId", "hide": "secret"}]}`,
+			expected: "unknown object field hide: secret",
+		},
+		{
+			name:     "unknown fodder kind",
+			input:    `{"__kind__": "Var", "id": "a", "fodder": [{"kind": -1}]}`,
+			expected: "unknown fodder kind: -1",
+		},
+		{
+			name:     "invalid string kind",
+			input:    `{"__kind__": "LiteralString", "value": "a", "kind": true}`,
+			expected: "string kind must be a string or a number",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := UnmarshalNode([]byte(tt.input))
+			assert.Error(t, err)
+			assert.Nil(t, node)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
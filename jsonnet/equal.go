@@ -0,0 +1,192 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+)
+
+func Equal(a any, b any, options EqualOptions) (bool, error) {
+	valA, err := canonicalValue(a, "a")
+	if err != nil {
+		return false, err
+	}
+	valB, err := canonicalValue(b, "b")
+	if err != nil {
+		return false, err
+	}
+	e := equaler{options: options}
+	return e.equal(e.normalize(valA), e.normalize(valB)), nil
+}
+
+// canonicalValue parses code or unmarshals an AST, canonicalizes it and turns
+// it back into a compact value for comparison.
+func canonicalValue(val any, name string) (any, error) {
+	var node ast.Node
+	switch v := val.(type) {
+	case string:
+		parsed, _, err := formatter.SnippetToRawAST(name+".jsonnet", v)
+		if err != nil {
+			return nil, err
+		}
+		node = parsed
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		node, err = UnmarshalNode(b)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a string or an AST", name)
+	}
+	canonicalize(node)
+	return nodeToValue(node, ParseOptions{Compact: true})
+}
+
+type equaler struct {
+	options EqualOptions
+}
+
+// normalize drops locations and whitespace, keeping only the comment text of
+// each fodder so that reformatted code compares equal.
+func (e equaler) normalize(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		if e.options.IgnoreParens && v["__kind__"] == "Parens" {
+			inner := e.normalize(v["inner"])
+			if m, ok := inner.(map[string]any); ok && !e.options.IgnoreComments {
+				comments := append(e.comments(v["fodder"]), e.comments(v["closeFodder"])...)
+				existing, _ := m["fodder"].([]any)
+				m["fodder"] = append(comments, existing...)
+			}
+			return inner
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		res := make(map[string]any, len(v))
+		comments := make([]any, 0)
+		hasFodder := false
+		for _, key := range keys {
+			switch {
+			case isFodderKey(key):
+				comments = append(comments, e.comments(v[key])...)
+				hasFodder = true
+			case ignoredKey(key):
+			default:
+				res[key] = e.normalize(v[key])
+			}
+		}
+		if hasFodder && !e.options.IgnoreComments {
+			res["fodder"] = comments
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, elem := range v {
+			res[i] = e.normalize(elem)
+		}
+		return res
+	default:
+		return val
+	}
+}
+
+func isFodderKey(key string) bool {
+	return strings.HasSuffix(key, "Fodder") || strings.HasPrefix(key, "fodder")
+}
+
+func (e equaler) comments(val any) []any {
+	res := make([]any, 0)
+	elems, ok := val.([]any)
+	if !ok {
+		return res
+	}
+	for _, elem := range elems {
+		fodder, ok := elem.(map[string]any)
+		if !ok {
+			continue
+		}
+		lines, _ := fodder["comment"].([]any)
+		for _, line := range lines {
+			if s, ok := line.(string); ok && strings.TrimSpace(s) != "" {
+				res = append(res, strings.TrimSpace(s))
+			}
+		}
+	}
+	return res
+}
+
+func (e equaler) equal(a any, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, val := range x {
+			other, ok := y[key]
+			if !ok {
+				return false
+			}
+			if key == "fields" && x["__kind__"] == "Object" && e.options.IgnoreFieldOrder {
+				if !e.equalUnordered(val, other) {
+					return false
+				}
+				continue
+			}
+			if !e.equal(val, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !e.equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func (e equaler) equalUnordered(a any, b any) bool {
+	x, ok := a.([]any)
+	if !ok {
+		return e.equal(a, b)
+	}
+	y, ok := b.([]any)
+	if !ok || len(x) != len(y) {
+		return false
+	}
+	used := make([]bool, len(y))
+	for _, elem := range x {
+		found := false
+		for j, other := range y {
+			if !used[j] && e.equal(elem, other) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func EqualJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "equalJsonnet",
+		Params: ast.Identifiers{"a", "b", "options"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 2 || len(input) > 3 {
+				return nil, fmt.Errorf("a and b must be provided")
+			}
+			var rawOptions any
+			if len(input) == 3 {
+				rawOptions = input[2]
+			}
+			options, err := NewEqualOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			out, err := Equal(input[0], input[1], options)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        any
+		b        any
+		options  any
+		expected bool
+	}{
+		{
+			name:     "formatting only",
+			a:        "{a: 1, b: [1, 2,]}",
+			b:        "{\n  a: 1,\n\n  b: [\n    1,\n    2,\n  ],\n}\n",
+			expected: true,
+		},
+		{
+			name:     "different value",
+			a:        "{ a: 1 }",
+			b:        "{ a: 2 }",
+			expected: false,
+		},
+		{
+			name:     "different comment",
+			a:        "// foo\n{ a: 1 }",
+			b:        "// bar\n{ a: 1 }",
+			expected: false,
+		},
+		{
+			name:     "moved comment",
+			a:        "// foo\n{ a: 1 }",
+			b:        "{\n  // foo\n  a: 1 }",
+			expected: false,
+		},
+		{
+			name:     "ignore comments",
+			a:        "// foo\n{ a: 1 }",
+			b:        "{ a: /* bar */ 1 }",
+			options:  map[string]any{"ignoreComments": true},
+			expected: true,
+		},
+		{
+			name:     "string quotes",
+			a:        "['x', 'it\\'s', @'a''b', 'tab\\t']",
+			b:        "[\"x\", \"it's\", \"a'b\", @\"tab\t\"]",
+			expected: true,
+		},
+		{
+			name:     "different string",
+			a:        "'x'",
+			b:        "\"y\"",
+			expected: false,
+		},
+		{
+			name:     "text block",
+			a:        "{\n  a: |||\n    x\n  |||,\n}",
+			b:        "{ a: 'x\\n' }",
+			expected: true,
+		},
+		{
+			name:     "field name quotes",
+			a:        "{ 'a-b': 1, c: 2 }",
+			b:        "{ \"a-b\": 1, 'c': 2 }",
+			expected: true,
+		},
+		{
+			name:     "index",
+			a:        "a.b + super.c",
+			b:        "a['b'] + super[\"c\"]",
+			expected: true,
+		},
+		{
+			name:     "parens",
+			a:        "(a) + 1",
+			b:        "a + 1",
+			expected: false,
+		},
+		{
+			name:     "ignore parens",
+			a:        "((a)) + 1",
+			b:        "a + 1",
+			options:  map[string]any{"ignoreParens": true},
+			expected: true,
+		},
+		{
+			name:     "ignore parens keeps comments",
+			a:        "/* foo */ (a) + 1",
+			b:        "a + 1",
+			options:  map[string]any{"ignoreParens": true},
+			expected: false,
+		},
+		{
+			name:     "field order",
+			a:        "{ a: 1, b: 2 }",
+			b:        "{ b: 2, a: 1 }",
+			expected: false,
+		},
+		{
+			name:     "ignore field order",
+			a:        "{ a: 1, b: 2 }",
+			b:        "{ b: 2, a: 1 }",
+			options:  map[string]any{"ignoreFieldOrder": true},
+			expected: true,
+		},
+		{
+			name:     "ignore field order with different values",
+			a:        "{ a: 1, b: 2 }",
+			b:        "{ b: 1, a: 2 }",
+			options:  map[string]any{"ignoreFieldOrder": true},
+			expected: false,
+		},
+		{
+			name: "ast",
+			a: map[string]any{
+				"__kind__": "Binary",
+				"left":     map[string]any{"__kind__": "Var", "id": "a"},
+				"op":       3,
+				"right":    map[string]any{"__kind__": "LiteralNumber", "originalString": "1"},
+			},
+			b:        "a + 1",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EqualJsonnet().Func([]any{tt.a, tt.b, tt.options})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEqualJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "a and b must be provided",
+		},
+		{
+			name:     "invalid a",
+			input:    []any{1, "null"},
+			expected: "a must be a string or an AST",
+		},
+		{
+			name:     "invalid b",
+			input:    []any{"null", true},
+			expected: "b must be a string or an AST",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"null", "{"},
+			expected: "b.jsonnet:1:2",
+		},
+		{
+			name:     "non-object options",
+			input:    []any{"null", "null", "foo"},
+			expected: "options must be an object",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{"null", "null", map[string]any{"ignoreFoo": true}},
+			expected: "invalid options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EqualJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type EqualOptions struct {
+	IgnoreComments   bool `json:"ignoreComments"`
+	IgnoreParens     bool `json:"ignoreParens"`
+	IgnoreFieldOrder bool `json:"ignoreFieldOrder"`
+}
+
+func DefaultEqualOptions() EqualOptions {
+	return EqualOptions{}
+}
+
+func NewEqualOptions(val any) (EqualOptions, error) {
+	options := DefaultEqualOptions()
+	if val == nil {
+		return options, nil
+	}
+	if _, ok := val.(map[string]any); !ok {
+		return options, fmt.Errorf("options must be an object")
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return options, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&options)
+	if err != nil {
+		return options, fmt.Errorf("invalid options: %w", err)
+	}
+	return options, nil
+}
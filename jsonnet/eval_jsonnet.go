@@ -0,0 +1,36 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func EvalJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "evalJsonnet",
+		Params: ast.Identifiers{"code", "options"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 1 || len(input) > 2 {
+				return nil, fmt.Errorf("code must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			var rawOptions any
+			if len(input) == 2 {
+				rawOptions = input[1]
+			}
+			options, err := NewEvaluateOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			out, err := Eval(DefaultFilename, code, options)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		options  map[string]any
+		expected any
+	}{
+		{
+			name:     "no options",
+			code:     "{ a: std.length('foo') }",
+			expected: map[string]any{"a": float64(3)},
+		},
+		{
+			name: "ext vars and tlas",
+			code: "function(name) { greeting: std.extVar('greeting') + ', ' + name }",
+			options: map[string]any{
+				"extVars": map[string]any{"greeting": "hello"},
+				"tlas":    map[string]any{"name": "world"},
+			},
+			expected: map[string]any{"greeting": "hello, world"},
+		},
+		{
+			name: "generated library",
+			code: "local lib = import 'lib/main.libsonnet'; lib.double(lib.data.n)",
+			options: map[string]any{
+				"files": map[string]any{
+					"lib/main.libsonnet": "{ double(n): n * 2, data: import './data/n.json' }",
+					"lib/data/n.json":    "{ \"n\": 21 }",
+				},
+			},
+			expected: float64(42),
+		},
+		{
+			name: "importstr",
+			code: "importstr 'script.sh'",
+			options: map[string]any{
+				"files": map[string]any{"script.sh": "echo foo\n"},
+			},
+			expected: "echo foo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvalJsonnet().Func([]any{tt.code, tt.options})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEvalJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code must be provided",
+		},
+		{
+			name:     "non-string argument",
+			input:    []any{123},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-object options",
+			input:    []any{"null", []any{}},
+			expected: "options must be an object",
+		},
+		{
+			name:     "missing file",
+			input:    []any{"import 'lib/missing.libsonnet'", map[string]any{"files": map[string]any{}}},
+			expected: "import not available: lib/missing.libsonnet",
+		},
+		{
+			name: "relative import from the root",
+			input: []any{"import 'lib/main.libsonnet'", map[string]any{"files": map[string]any{
+				"lib/main.libsonnet": "import 'util.libsonnet'",
+				"util.libsonnet":     "{}",
+			}}},
+			expected: "import not available: util.libsonnet",
+		},
+		{
+			name:     "no disk access",
+			input:    []any{"importstr '/etc/hostname'"},
+			expected: "import not available: /etc/hostname",
+		},
+		{
+			name:     "syntax error",
+			input:    []any{"{ a: }"},
+			expected: "main.jsonnet:1:6-7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvalJsonnet().Func(tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
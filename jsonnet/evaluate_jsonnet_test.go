@@ -0,0 +1,135 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonnet  string
+		options  map[string]any
+		expected any
+	}{
+		{
+			name:     "literal",
+			jsonnet:  "{ a: 1 + 2, b: [true, null, 'c'] }",
+			expected: map[string]any{"a": float64(3), "b": []any{true, nil, "c"}},
+		},
+		{
+			name:    "ext vars",
+			jsonnet: "[std.extVar('a'), std.extVar('b').c]",
+			options: map[string]any{
+				"extVars": map[string]any{"a": "foo", "b": map[string]any{"c": float64(1)}},
+			},
+			expected: []any{"foo", float64(1)},
+		},
+		{
+			name:    "tlas",
+			jsonnet: "function(a, b) a + b",
+			options: map[string]any{
+				"tlas": map[string]any{"a": float64(1), "b": float64(2)},
+			},
+			expected: float64(3),
+		},
+		{
+			name:    "files",
+			jsonnet: "(import 'lib/a.libsonnet').a",
+			options: map[string]any{
+				"files": map[string]any{
+					"lib/a.libsonnet": "{ a: import 'b.libsonnet' }",
+					"lib/b.libsonnet": "'b'",
+				},
+			},
+			expected: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(DefaultFilename, tt.jsonnet, DefaultParseOptions())
+			require.NoError(t, err)
+
+			result, err := EvaluateJsonnet().Func([]any{node, tt.options})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEvaluateJsonnetErrors(t *testing.T) {
+	importDisk, err := Parse(DefaultFilename, "importstr '/etc/hostname'", DefaultParseOptions())
+	require.NoError(t, err)
+	runtimeError, err := Parse(DefaultFilename, "error 'foo'", DefaultParseOptions())
+	require.NoError(t, err)
+	runtimeLocation, err := Parse(DefaultFilename, "local   a =   error 'foo';\n\n\n a", DefaultParseOptions())
+	require.NoError(t, err)
+	staticLocation, err := Parse(DefaultFilename, "{\n  a:    b,\n}", DefaultParseOptions())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "jsonnet must be provided",
+		},
+		{
+			name:     "too many arguments",
+			input:    []any{nil, nil, "extra"},
+			expected: "jsonnet must be provided",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{nil, map[string]any{"jpath": []any{"/"}}},
+			expected: "unknown field \"jpath\"",
+		},
+		{
+			name:     "mistyped files",
+			input:    []any{nil, map[string]any{"files": map[string]any{"a": true}}},
+			expected: "invalid options",
+		},
+		{
+			name:     "unsupported node",
+			input:    []any{map[string]any{"__kind__": "Bogus"}},
+			expected: "unsupported node kind \"Bogus\" at $",
+		},
+		{
+			name:     "import from disk",
+			input:    []any{importDisk},
+			expected: "import not available: /etc/hostname",
+		},
+		{
+			name:     "runtime error",
+			input:    []any{runtimeError},
+			expected: "RUNTIME ERROR: foo",
+		},
+		{
+			name:     "runtime error location",
+			input:    []any{runtimeLocation},
+			expected: "RUNTIME ERROR: foo\n\tmain.jsonnet:1:15-26\t",
+		},
+		{
+			name:     "static error location",
+			input:    []any{staticLocation},
+			expected: "STATIC ERROR: main.jsonnet:2:9-10 Unknown variable: b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvaluateJsonnet().Func(tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-jsonnet"
+)
+
+type EvaluateOptions struct {
+	ExtVars map[string]any    `json:"extVars"`
+	Tlas    map[string]any    `json:"tlas"`
+	Files   map[string]string `json:"files"`
+}
+
+func DefaultEvaluateOptions() EvaluateOptions {
+	return EvaluateOptions{}
+}
+
+func NewEvaluateOptions(val any) (EvaluateOptions, error) {
+	options := DefaultEvaluateOptions()
+	if val == nil {
+		return options, nil
+	}
+	if _, ok := val.(map[string]any); !ok {
+		return options, fmt.Errorf("options must be an object")
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return options, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&options)
+	if err != nil {
+		return options, fmt.Errorf("invalid options: %w", err)
+	}
+	return options, nil
+}
+
+func (o EvaluateOptions) VM() (*jsonnet.VM, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&MemoryImporter{Files: o.Files})
+	for key, val := range o.ExtVars {
+		if s, ok := val.(string); ok {
+			vm.ExtVar(key, s)
+			continue
+		}
+		code, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		vm.ExtCode(key, string(code))
+	}
+	for key, val := range o.Tlas {
+		if s, ok := val.(string); ok {
+			vm.TLAVar(key, s)
+			continue
+		}
+		code, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		vm.TLACode(key, string(code))
+	}
+	return vm, nil
+}
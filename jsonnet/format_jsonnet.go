@@ -10,16 +10,31 @@ import (
 func FormatJsonnet() jsonnet.NativeFunction {
 	return jsonnet.NativeFunction{
 		Name:   "formatJsonnet",
-		Params: ast.Identifiers{"code"},
+		Params: ast.Identifiers{"code", "options", "filename"},
 		Func: func(input []any) (any, error) {
-			if len(input) != 1 {
+			if len(input) < 1 || len(input) > 3 {
 				return nil, fmt.Errorf("code must be provided")
 			}
 			code, ok := input[0].(string)
 			if !ok {
 				return nil, fmt.Errorf("code must be a string")
 			}
-			formattedCode, err := formatter.Format("main.jsonnet", code, formatter.DefaultOptions())
+			var rawOptions any
+			if len(input) >= 2 {
+				rawOptions = input[1]
+			}
+			options, err := NewFormatOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			filename := DefaultFilename
+			if len(input) == 3 && input[2] != nil {
+				filename, ok = input[2].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			formattedCode, err := formatter.Format(filename, code, options)
 			if err != nil {
 				return nil, err
 			}
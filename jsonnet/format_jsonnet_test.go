@@ -0,0 +1,69 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatJsonnetOptions(t *testing.T) {
+	options := map[string]any{
+		"indent":       float64(4),
+		"stringStyle":  "double",
+		"commentStyle": "leave",
+	}
+	result, err := FormatJsonnet().Func([]any{"{a: 'foo', # comment\n b: 1}", options})
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n    a: \"foo\",  # comment\n    b: 1,\n}\n", result)
+}
+
+func TestFormatJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code must be provided",
+		},
+		{
+			name:     "too many arguments",
+			input:    []any{"code", nil, "main.jsonnet", "extra"},
+			expected: "code must be provided",
+		},
+		{
+			name:     "non-string argument",
+			input:    []any{123},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-string filename",
+			input:    []any{"null", nil, 123},
+			expected: "filename must be a string",
+		},
+		{
+			name:     "default filename",
+			input:    []any{"{a: }"},
+			expected: "main.jsonnet:1:5-6",
+		},
+		{
+			name:     "filename",
+			input:    []any{"{a: }", nil, "lib/config.libsonnet"},
+			expected: "lib/config.libsonnet:1:5-6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatJsonnet().Func(tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-jsonnet/formatter"
+)
+
+func NewFormatOptions(val any) (formatter.Options, error) {
+	options := formatter.DefaultOptions()
+	if val == nil {
+		return options, nil
+	}
+	if _, ok := val.(map[string]any); !ok {
+		return options, fmt.Errorf("options must be an object")
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return options, err
+	}
+	proxy := NewProxyFormatOptions(options)
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&proxy)
+	if err != nil {
+		return options, fmt.Errorf("invalid options: %w", err)
+	}
+	return proxy.Options(), nil
+}
+
+type ProxyFormatOptions struct {
+	Indent              int          `json:"indent"`
+	MaxBlankLines       int          `json:"maxBlankLines"`
+	StringStyle         StringStyle  `json:"stringStyle"`
+	CommentStyle        CommentStyle `json:"commentStyle"`
+	PrettyFieldNames    bool         `json:"prettyFieldNames"`
+	PadArrays           bool         `json:"padArrays"`
+	PadObjects          bool         `json:"padObjects"`
+	SortImports         bool         `json:"sortImports"`
+	UseImplicitPlus     bool         `json:"useImplicitPlus"`
+	StripEverything     bool         `json:"stripEverything"`
+	StripComments       bool         `json:"stripComments"`
+	StripAllButComments bool         `json:"stripAllButComments"`
+}
+
+func NewProxyFormatOptions(o formatter.Options) ProxyFormatOptions {
+	proxy := ProxyFormatOptions{}
+	proxy.Indent = o.Indent
+	proxy.MaxBlankLines = o.MaxBlankLines
+	proxy.StringStyle = StringStyle(o.StringStyle)
+	proxy.CommentStyle = CommentStyle(o.CommentStyle)
+	proxy.PrettyFieldNames = o.PrettyFieldNames
+	proxy.PadArrays = o.PadArrays
+	proxy.PadObjects = o.PadObjects
+	proxy.SortImports = o.SortImports
+	proxy.UseImplicitPlus = o.UseImplicitPlus
+	proxy.StripEverything = o.StripEverything
+	proxy.StripComments = o.StripComments
+	proxy.StripAllButComments = o.StripAllButComments
+	return proxy
+}
+
+func (p ProxyFormatOptions) Options() formatter.Options {
+	o := formatter.Options{}
+	o.Indent = p.Indent
+	o.MaxBlankLines = p.MaxBlankLines
+	o.StringStyle = formatter.StringStyle(p.StringStyle)
+	o.CommentStyle = formatter.CommentStyle(p.CommentStyle)
+	o.PrettyFieldNames = p.PrettyFieldNames
+	o.PadArrays = p.PadArrays
+	o.PadObjects = p.PadObjects
+	o.SortImports = p.SortImports
+	o.UseImplicitPlus = p.UseImplicitPlus
+	o.StripEverything = p.StripEverything
+	o.StripComments = p.StripComments
+	o.StripAllButComments = p.StripAllButComments
+	return o
+}
+
+type StringStyle formatter.StringStyle
+
+var stringStyles = map[string]formatter.StringStyle{
+	"double": formatter.StringStyleDouble,
+	"single": formatter.StringStyleSingle,
+	"leave":  formatter.StringStyleLeave,
+}
+
+func (s StringStyle) MarshalJSON() ([]byte, error) {
+	for name, style := range stringStyles {
+		if style == formatter.StringStyle(s) {
+			return json.Marshal(name)
+		}
+	}
+	return nil, fmt.Errorf("unknown string style: %d", s)
+}
+
+func (s *StringStyle) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	style, ok := stringStyles[name]
+	if !ok {
+		return fmt.Errorf("unknown string style: %s", name)
+	}
+	*s = StringStyle(style)
+	return nil
+}
+
+type CommentStyle formatter.CommentStyle
+
+var commentStyles = map[string]formatter.CommentStyle{
+	"hash":  formatter.CommentStyleHash,
+	"slash": formatter.CommentStyleSlash,
+	"leave": formatter.CommentStyleLeave,
+}
+
+func (c CommentStyle) MarshalJSON() ([]byte, error) {
+	for name, style := range commentStyles {
+		if style == formatter.CommentStyle(c) {
+			return json.Marshal(name)
+		}
+	}
+	return nil, fmt.Errorf("unknown comment style: %d", c)
+}
+
+func (c *CommentStyle) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	style, ok := commentStyles[name]
+	if !ok {
+		return fmt.Errorf("unknown comment style: %s", name)
+	}
+	*c = CommentStyle(style)
+	return nil
+}
@@ -0,0 +1,123 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormatOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected func(o *formatter.Options)
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: func(o *formatter.Options) {},
+		},
+		{
+			name:     "empty",
+			input:    map[string]any{},
+			expected: func(o *formatter.Options) {},
+		},
+		{
+			name: "all fields",
+			input: map[string]any{
+				"indent":              float64(4),
+				"maxBlankLines":       float64(1),
+				"stringStyle":         "double",
+				"commentStyle":        "leave",
+				"prettyFieldNames":    false,
+				"padArrays":           true,
+				"padObjects":          false,
+				"sortImports":         false,
+				"useImplicitPlus":     false,
+				"stripEverything":     true,
+				"stripComments":       true,
+				"stripAllButComments": true,
+			},
+			expected: func(o *formatter.Options) {
+				o.Indent = 4
+				o.MaxBlankLines = 1
+				o.StringStyle = formatter.StringStyleDouble
+				o.CommentStyle = formatter.CommentStyleLeave
+				o.PrettyFieldNames = false
+				o.PadArrays = true
+				o.PadObjects = false
+				o.SortImports = false
+				o.UseImplicitPlus = false
+				o.StripEverything = true
+				o.StripComments = true
+				o.StripAllButComments = true
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := NewFormatOptions(tt.input)
+			require.NoError(t, err)
+
+			expected := formatter.DefaultOptions()
+			tt.expected(&expected)
+			assert.Equal(t, expected, options)
+		})
+	}
+}
+
+func TestNewFormatOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{
+			name:     "non-object",
+			input:    "double",
+			expected: "options must be an object",
+		},
+		{
+			name:     "unknown key",
+			input:    map[string]any{"indentation": float64(4)},
+			expected: "unknown field \"indentation\"",
+		},
+		{
+			name:     "mistyped int",
+			input:    map[string]any{"indent": "4"},
+			expected: "invalid options",
+		},
+		{
+			name:     "fractional int",
+			input:    map[string]any{"indent": 2.5},
+			expected: "invalid options",
+		},
+		{
+			name:     "mistyped bool",
+			input:    map[string]any{"padArrays": "yes"},
+			expected: "invalid options",
+		},
+		{
+			name:     "unknown string style",
+			input:    map[string]any{"stringStyle": "backtick"},
+			expected: "unknown string style: backtick",
+		},
+		{
+			name:     "unknown comment style",
+			input:    map[string]any{"commentStyle": float64(0)},
+			expected: "invalid options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFormatOptions(tt.input)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
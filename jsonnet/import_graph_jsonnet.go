@@ -0,0 +1,39 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func ImportGraphJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "importGraph",
+		Params: ast.Identifiers{"rootPath", "files"},
+		Func: func(input []any) (any, error) {
+			if len(input) != 2 {
+				return nil, fmt.Errorf("rootPath and files must be provided")
+			}
+			rootPath, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("rootPath must be a string")
+			}
+			rawFiles, ok := input[1].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("files must be an object")
+			}
+			files := make(map[string]string, len(rawFiles))
+			for filename, content := range rawFiles {
+				files[filename], ok = content.(string)
+				if !ok {
+					return nil, fmt.Errorf("file %s must be a string", filename)
+				}
+			}
+			out, err := BuildImportGraph(rootPath, files)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
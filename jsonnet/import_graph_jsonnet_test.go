@@ -0,0 +1,198 @@
+package jsonnet
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGraphJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]any
+		edges    map[string][]string
+		cycles   []any
+		missing  []string
+		fileKind map[string]string
+	}{
+		{
+			name: "transitive",
+			files: map[string]any{
+				"main.jsonnet":          "local lib = import 'lib/main.libsonnet'; lib",
+				"lib/main.libsonnet":    "{ util: import 'util.libsonnet', script: importstr '../script.sh' }",
+				"lib/util.libsonnet":    "{}",
+				"script.sh":             "echo",
+				"unused/util.libsonnet": "{}",
+			},
+			edges: map[string][]string{
+				"main.jsonnet":       {"lib/main.libsonnet"},
+				"lib/main.libsonnet": {"lib/util.libsonnet", "script.sh"},
+				"lib/util.libsonnet": {},
+				"script.sh":          {},
+			},
+			cycles:  []any{},
+			missing: []string{},
+			fileKind: map[string]string{
+				"main.jsonnet": "import",
+				"script.sh":    "importstr",
+			},
+		},
+		{
+			name: "cycle",
+			files: map[string]any{
+				"main.jsonnet": "import 'a.libsonnet'",
+				"a.libsonnet":  "import 'b.libsonnet'",
+				"b.libsonnet":  "[import 'a.libsonnet', import 'main.jsonnet']",
+			},
+			edges: map[string][]string{
+				"main.jsonnet": {"a.libsonnet"},
+				"a.libsonnet":  {"b.libsonnet"},
+				"b.libsonnet":  {"a.libsonnet", "main.jsonnet"},
+			},
+			cycles: []any{
+				[]any{"a.libsonnet", "b.libsonnet", "a.libsonnet"},
+				[]any{"a.libsonnet", "b.libsonnet", "main.jsonnet", "a.libsonnet"},
+			},
+			missing: []string{},
+		},
+		{
+			name: "overlapping cycles",
+			files: map[string]any{
+				"main.jsonnet": "import 'a.libsonnet'",
+				"a.libsonnet":  "[import 'b.libsonnet', import 'c.libsonnet']",
+				"b.libsonnet":  "[import 'a.libsonnet', import 'a.libsonnet']",
+				"c.libsonnet":  "import 'b.libsonnet'",
+			},
+			edges: map[string][]string{
+				"main.jsonnet": {"a.libsonnet"},
+				"a.libsonnet":  {"b.libsonnet", "c.libsonnet"},
+				"b.libsonnet":  {"a.libsonnet", "a.libsonnet"},
+				"c.libsonnet":  {"b.libsonnet"},
+			},
+			cycles: []any{
+				[]any{"a.libsonnet", "b.libsonnet", "a.libsonnet"},
+				[]any{"a.libsonnet", "c.libsonnet", "b.libsonnet", "a.libsonnet"},
+			},
+			missing: []string{},
+		},
+		{
+			name: "self import string is not a cycle",
+			files: map[string]any{
+				"main.jsonnet": "importstr 'main.jsonnet'",
+			},
+			edges: map[string][]string{
+				"main.jsonnet": {"main.jsonnet"},
+			},
+			cycles:  []any{},
+			missing: []string{},
+		},
+		{
+			name: "missing",
+			files: map[string]any{
+				"main.jsonnet": "import 'missing.libsonnet'",
+			},
+			edges: map[string][]string{
+				"main.jsonnet": {""},
+			},
+			cycles:  []any{},
+			missing: []string{"missing.libsonnet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ImportGraphJsonnet().Func([]any{"main.jsonnet", tt.files})
+			require.NoError(t, err)
+			graph := result.(map[string]any)
+
+			assert.Equal(t, "main.jsonnet", graph["root"])
+			edges := map[string][]string{}
+			for filename, file := range graph["files"].(map[string]any) {
+				edges[filename] = []string{}
+				for _, ref := range file.(map[string]any)["imports"].([]any) {
+					resolved, _ := ref.(map[string]any)["resolved"].(string)
+					edges[filename] = append(edges[filename], resolved)
+				}
+			}
+			assert.Equal(t, tt.edges, edges)
+			assert.Equal(t, tt.cycles, graph["cycles"])
+			missing := []string{}
+			for _, ref := range graph["missing"].([]any) {
+				missing = append(missing, ref.(map[string]any)["path"].(string))
+			}
+			assert.Equal(t, tt.missing, missing)
+			for filename, kind := range tt.fileKind {
+				assert.Equal(t, kind, graph["files"].(map[string]any)[filename].(map[string]any)["kind"])
+			}
+		})
+	}
+}
+
+func TestImportGraphJsonnetDense(t *testing.T) {
+	files := map[string]any{"main.jsonnet": "import 'a00.libsonnet'"}
+	for i := 0; i < 40; i++ {
+		imports := []string{}
+		for j := i + 1; j < 40; j++ {
+			imports = append(imports, fmt.Sprintf("import 'a%02d.libsonnet'", j))
+		}
+		if i == 39 {
+			imports = append(imports, "import 'a38.libsonnet'")
+		}
+		files[fmt.Sprintf("a%02d.libsonnet", i)] = "[" + strings.Join(imports, ", ") + "]"
+	}
+
+	result, err := ImportGraphJsonnet().Func([]any{"main.jsonnet", files})
+	require.NoError(t, err)
+	assert.Equal(t, []any{[]any{"a38.libsonnet", "a39.libsonnet", "a38.libsonnet"}}, result.(map[string]any)["cycles"])
+}
+
+func TestImportGraphJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "rootPath and files must be provided",
+		},
+		{
+			name:     "non-string root path",
+			input:    []any{123, map[string]any{}},
+			expected: "rootPath must be a string",
+		},
+		{
+			name:     "non-object files",
+			input:    []any{"main.jsonnet", []any{}},
+			expected: "files must be an object",
+		},
+		{
+			name:     "non-string file",
+			input:    []any{"main.jsonnet", map[string]any{"main.jsonnet": 1}},
+			expected: "file main.jsonnet must be a string",
+		},
+		{
+			name:     "missing root",
+			input:    []any{"main.jsonnet", map[string]any{}},
+			expected: "root file not found: main.jsonnet",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"main.jsonnet", map[string]any{"main.jsonnet": "import 'a.libsonnet'", "a.libsonnet": "{"}},
+			expected: "a.libsonnet:1:2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ImportGraphJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package jsonnet
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/google/go-jsonnet"
+)
+
+type MemoryImporter struct {
+	Files map[string]string
+}
+
+func (m *MemoryImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	resolved, ok := m.Resolve(importedFrom, importedPath)
+	if !ok {
+		return jsonnet.Contents{}, "", fmt.Errorf("import not available: %s", importedPath)
+	}
+	return jsonnet.MakeContents(m.Files[resolved]), resolved, nil
+}
+
+// Resolve looks an import up next to the file it is imported from, as the
+// Jsonnet importer does when no library paths are given.
+func (m *MemoryImporter) Resolve(importedFrom, importedPath string) (string, bool) {
+	resolved := importedPath
+	if !path.IsAbs(importedPath) {
+		resolved = path.Join(path.Dir(importedFrom), importedPath)
+	}
+	if _, ok := m.Files[resolved]; !ok {
+		return "", false
+	}
+	return resolved, true
+}
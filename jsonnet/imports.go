@@ -0,0 +1,241 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/google/go-jsonnet/toolutils"
+)
+
+type ImportKind string
+
+const (
+	ImportKindImport    ImportKind = "import"
+	ImportKindImportStr ImportKind = "importstr"
+	ImportKindImportBin ImportKind = "importbin"
+)
+
+type ImportRef struct {
+	Path     string        `json:"path"`
+	Kind     ImportKind    `json:"kind"`
+	Resolved string        `json:"resolved,omitempty"`
+	LocRange LocationRange `json:"locRange"`
+}
+
+func Imports(filename string, code string) (any, error) {
+	refs, err := FindImports(filename, code)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(refs)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0)
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func FindImports(filename string, code string) ([]ImportRef, error) {
+	node, _, err := formatter.SnippetToRawAST(filename, code)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]ImportRef, 0)
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		var ref ImportRef
+		switch n := node.(type) {
+		case nil:
+			return
+		case *ast.Import:
+			ref = ImportRef{Path: n.File.Value, Kind: ImportKindImport}
+		case *ast.ImportStr:
+			ref = ImportRef{Path: n.File.Value, Kind: ImportKindImportStr}
+		case *ast.ImportBin:
+			ref = ImportRef{Path: n.File.Value, Kind: ImportKindImportBin}
+		default:
+			for _, child := range toolutils.Children(node) {
+				walk(child)
+			}
+			return
+		}
+		locRange := *node.Loc()
+		locRange.File = nil
+		locRange.FileName = filename
+		ref.LocRange = NewLocationRange(locRange)
+		refs = append(refs, ref)
+	}
+	walk(node)
+	sort.SliceStable(refs, func(i, j int) bool {
+		return locationLess(refs[i].LocRange.Begin, refs[j].LocRange.Begin)
+	})
+	return refs, nil
+}
+
+type ImportGraph struct {
+	Root    string                     `json:"root"`
+	Files   map[string]ImportGraphFile `json:"files"`
+	Cycles  [][]string                 `json:"cycles"`
+	Missing []ImportRef                `json:"missing"`
+}
+
+type ImportGraphFile struct {
+	Kind    ImportKind  `json:"kind"`
+	Imports []ImportRef `json:"imports"`
+}
+
+func BuildImportGraph(rootPath string, files map[string]string) (any, error) {
+	if _, ok := files[rootPath]; !ok {
+		return nil, fmt.Errorf("root file not found: %s", rootPath)
+	}
+	g := importGraphBuilder{
+		importer: &MemoryImporter{Files: files},
+		graph: ImportGraph{
+			Root:    rootPath,
+			Files:   make(map[string]ImportGraphFile),
+			Cycles:  make([][]string, 0),
+			Missing: make([]ImportRef, 0),
+		},
+	}
+	err := g.visit(rootPath, ImportKindImport)
+	if err != nil {
+		return nil, err
+	}
+	g.findCycles()
+	b, err := json.Marshal(g.graph)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]any)
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type importGraphBuilder struct {
+	importer *MemoryImporter
+	graph    ImportGraph
+}
+
+func (g *importGraphBuilder) visit(filename string, kind ImportKind) error {
+	if existing, ok := g.graph.Files[filename]; ok && (existing.Kind == ImportKindImport || kind != ImportKindImport) {
+		return nil
+	}
+	file := ImportGraphFile{Kind: kind, Imports: make([]ImportRef, 0)}
+	if kind != ImportKindImport {
+		g.graph.Files[filename] = file
+		return nil
+	}
+	refs, err := FindImports(filename, g.importer.Files[filename])
+	if err != nil {
+		return err
+	}
+	file.Imports = refs
+	g.graph.Files[filename] = file
+	for i := range file.Imports {
+		ref := &file.Imports[i]
+		resolved, ok := g.importer.Resolve(filename, ref.Path)
+		if !ok {
+			g.graph.Missing = append(g.graph.Missing, *ref)
+			continue
+		}
+		ref.Resolved = resolved
+		err = g.visit(resolved, ref.Kind)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findCycles lists every elementary cycle of imports once, starting at its
+// smallest file, using Johnson's algorithm: the search from each file only
+// passes through larger files, and files stay blocked until a cycle is found
+// through them, so the time spent is linear in the size of the graph for
+// every cycle found.
+func (g *importGraphBuilder) findCycles() {
+	filenames := make([]string, 0, len(g.graph.Files))
+	for filename := range g.graph.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, start := range filenames {
+		s := cycleSearch{
+			g:         g,
+			start:     start,
+			blocked:   make(map[string]bool),
+			blockedBy: make(map[string]map[string]bool),
+		}
+		s.circuit(start)
+	}
+}
+
+type cycleSearch struct {
+	g         *importGraphBuilder
+	start     string
+	path      []string
+	blocked   map[string]bool
+	blockedBy map[string]map[string]bool
+}
+
+// circuit reports the cycles through start that continue the path with
+// filename, returning whether there were any.
+func (s *cycleSearch) circuit(filename string) bool {
+	found := false
+	s.path = append(s.path, filename)
+	s.blocked[filename] = true
+	next := s.next(filename)
+	for _, imported := range next {
+		if imported == s.start {
+			s.g.graph.Cycles = append(s.g.graph.Cycles, append(append([]string{}, s.path...), s.start))
+			found = true
+		} else if !s.blocked[imported] && s.circuit(imported) {
+			found = true
+		}
+	}
+	if found {
+		s.unblock(filename)
+	} else {
+		for _, imported := range next {
+			if s.blockedBy[imported] == nil {
+				s.blockedBy[imported] = make(map[string]bool)
+			}
+			s.blockedBy[imported][filename] = true
+		}
+	}
+	s.path = s.path[:len(s.path)-1]
+	return found
+}
+
+// next lists the files filename imports that the search may go on with.
+func (s *cycleSearch) next(filename string) []string {
+	var next []string
+	followed := make(map[string]bool)
+	for _, ref := range s.g.graph.Files[filename].Imports {
+		if ref.Kind != ImportKindImport || ref.Resolved < s.start || followed[ref.Resolved] {
+			continue
+		}
+		followed[ref.Resolved] = true
+		next = append(next, ref.Resolved)
+	}
+	return next
+}
+
+func (s *cycleSearch) unblock(filename string) {
+	s.blocked[filename] = false
+	for waiting := range s.blockedBy[filename] {
+		delete(s.blockedBy[filename], waiting)
+		if s.blocked[waiting] {
+			s.unblock(waiting)
+		}
+	}
+}
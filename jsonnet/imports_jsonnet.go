@@ -0,0 +1,35 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func ImportsJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "importsJsonnet",
+		Params: ast.Identifiers{"code", "filename"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 1 || len(input) > 2 {
+				return nil, fmt.Errorf("code must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			filename := DefaultFilename
+			if len(input) == 2 && input[1] != nil {
+				filename, ok = input[1].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			out, err := Imports(filename, code)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportsJsonnet(t *testing.T) {
+	code := "local lib = import 'lib.libsonnet';\n{\n  script: importstr './script.sh',\n  data: importbin 'data.bin',\n  nested: [import 'nested.json'],\n}\n"
+
+	result, err := ImportsJsonnet().Func([]any{code, "dir/main.jsonnet"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{
+		importRef("lib.libsonnet", "import", "dir/main.jsonnet", 1, 13, 1, 35),
+		importRef("./script.sh", "importstr", "dir/main.jsonnet", 3, 11, 3, 34),
+		importRef("data.bin", "importbin", "dir/main.jsonnet", 4, 9, 4, 29),
+		importRef("nested.json", "import", "dir/main.jsonnet", 5, 12, 5, 32),
+	}, result)
+}
+
+func TestImportsJsonnetNone(t *testing.T) {
+	result, err := ImportsJsonnet().Func([]any{"{ a: 1 }"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{}, result)
+}
+
+func importRef(path, kind, fileName string, beginLine, beginColumn, endLine, endColumn int) map[string]any {
+	return map[string]any{
+		"path": path,
+		"kind": kind,
+		"locRange": map[string]any{
+			"file":     nil,
+			"fileName": fileName,
+			"begin":    map[string]any{"line": float64(beginLine), "column": float64(beginColumn)},
+			"end":      map[string]any{"line": float64(endLine), "column": float64(endColumn)},
+		},
+	}
+}
+
+func TestImportsJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code must be provided",
+		},
+		{
+			name:     "non-string code",
+			input:    []any{123},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-string filename",
+			input:    []any{"null", 123},
+			expected: "filename must be a string",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"import"},
+			expected: "main.jsonnet:1:7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ImportsJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
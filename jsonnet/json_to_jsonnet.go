@@ -0,0 +1,28 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func JsonToJsonnetJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "jsonToJsonnet",
+		Params: ast.Identifiers{"text"},
+		Func: func(input []any) (any, error) {
+			if len(input) != 1 {
+				return nil, fmt.Errorf("text must be provided")
+			}
+			text, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("text must be a string")
+			}
+			out, err := JsonToJsonnet(text)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonToJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "scalar",
+			input:    `"foo"`,
+			expected: "'foo'\n",
+		},
+		{
+			name:     "numbers",
+			input:    `[1, -2.5e3, 0.10]`,
+			expected: "[\n  1,\n  -2.5e3,\n  0.10,\n]\n",
+		},
+		{
+			name:     "object keeps key order",
+			input:    `{"b": null, "a-b": true, "local": "it's", "a": {}}`,
+			expected: "{\n  b: null,\n  'a-b': true,\n  'local': \"it's\",\n  a: {},\n}\n",
+		},
+		{
+			name:     "nested",
+			input:    `{"a": [{"b": []}]}`,
+			expected: "{\n  a: [\n    {\n      b: [],\n    },\n  ],\n}\n",
+		},
+		{
+			name:     "escapes",
+			input:    `{"b": "x\\y", "it's": "line\nnext \"\u00fc\""}`,
+			expected: "{\n  b: 'x\\\\y',\n  \"it's\": 'line\\nnext \"ü\"',\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := JsonToJsonnetJsonnet().Func([]any{tt.input})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestJsonToJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "text must be provided",
+		},
+		{
+			name:     "not a string",
+			input:    []any{1},
+			expected: "text must be a string",
+		},
+		{
+			name:     "empty",
+			input:    []any{""},
+			expected: "invalid JSON: unexpected EOF",
+		},
+		{
+			name:     "truncated",
+			input:    []any{`{"a": [1,`},
+			expected: "invalid JSON",
+		},
+		{
+			name:     "trailing data",
+			input:    []any{`1 2`},
+			expected: "invalid JSON: unexpected data after top-level value",
+		},
+		{
+			name:     "duplicate key",
+			input:    []any{`{"a": 1, "a": 2}`},
+			expected: `invalid JSON: duplicate key "a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := JsonToJsonnetJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
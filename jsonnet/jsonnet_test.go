@@ -1,9 +1,11 @@
 package jsonnet
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"github.com/google/go-jsonnet/formatter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,6 +48,30 @@ func TestParseManifest(t *testing.T) {
 			name:    "string/format",
 			jsonnet: "'foobar %s' % ['baz']",
 		},
+		{
+			name:    "string/double quote",
+			jsonnet: "\"foo'bar\"",
+		},
+		{
+			name:    "string/text block",
+			jsonnet: "|||\n  foo\n  bar\n|||",
+		},
+		{
+			name:    "string/text block chomped",
+			jsonnet: "|||-\n  foo\n|||",
+		},
+		{
+			name:    "string/text block nested",
+			jsonnet: "{\n  a: |||\n    #!/bin/sh\n    echo 'foo'\n  |||,\n}",
+		},
+		{
+			name:    "string/verbatim single",
+			jsonnet: "@'foo\\bar'",
+		},
+		{
+			name:    "string/verbatim double",
+			jsonnet: "@\"foo\\bar\"",
+		},
 		{
 			name:    "number/single digit",
 			jsonnet: "3",
@@ -126,6 +152,18 @@ func TestParseManifest(t *testing.T) {
 			name:    "object/field expr func",
 			jsonnet: "{ [a](): 1 }",
 		},
+		{
+			name:    "object/field hidden",
+			jsonnet: "{ a:: 1, b():: 2 }",
+		},
+		{
+			name:    "object/field forced",
+			jsonnet: "{ a::: 1, b()::: 2 }",
+		},
+		{
+			name:    "object/field plus",
+			jsonnet: "{ a+: 1, b+:: 2, [c]+::: 3 }",
+		},
 		{
 			name:    "object/local",
 			jsonnet: "{ local a = 1, b: a }",
@@ -191,6 +229,18 @@ func TestParseManifest(t *testing.T) {
 			name:    "error/message",
 			jsonnet: "error 'foo'",
 		},
+		{
+			name:    "import/import",
+			jsonnet: "import 'foo.libsonnet'",
+		},
+		{
+			name:    "import/importstr",
+			jsonnet: "importstr 'foo.txt'",
+		},
+		{
+			name:    "import/importbin",
+			jsonnet: "importbin 'foo.bin'",
+		},
 		{
 			name:    "comment/simple",
 			jsonnet: "// Output the number one\n1",
@@ -213,10 +263,10 @@ func TestParseManifest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			node, err := Parse(tt.jsonnet)
+			node, err := Parse(DefaultFilename, tt.jsonnet, DefaultParseOptions())
 			require.NoError(t, err)
 
-			resultStr, err := Manifest(node)
+			resultStr, err := Manifest(node, formatter.DefaultOptions())
 			require.NoError(t, err)
 
 			canonical := tt.canonical
@@ -225,6 +275,46 @@ func TestParseManifest(t *testing.T) {
 			}
 
 			assert.Equal(t, canonical, strings.TrimSuffix(resultStr, "\n"), "Result should match jsonnet")
+
+			compactNode, err := Parse(DefaultFilename, tt.jsonnet, ParseOptions{Compact: true})
+			require.NoError(t, err)
+
+			compactStr, err := Manifest(compactNode, formatter.DefaultOptions())
+			require.NoError(t, err)
+
+			assert.Equal(t, resultStr, compactStr, "Compact result should match full result")
 		})
 	}
 }
+
+func TestParseCompact(t *testing.T) {
+	jsonnet := "local a = { b: [1, 2, 3] };\n\n// comment\na.b[0]"
+
+	full, err := Parse(DefaultFilename, jsonnet, DefaultParseOptions())
+	require.NoError(t, err)
+	fullJson, err := json.Marshal(full)
+	require.NoError(t, err)
+
+	compact, err := Parse(DefaultFilename, jsonnet, ParseOptions{Compact: true})
+	require.NoError(t, err)
+	compactJson, err := json.Marshal(compact)
+	require.NoError(t, err)
+
+	assert.Less(t, len(compactJson), len(fullJson))
+	assert.NotContains(t, string(compactJson), `"lines"`)
+	assert.NotContains(t, string(compactJson), `"context"`)
+	assert.NotContains(t, string(compactJson), `"freeVars"`)
+
+	locRange := compact.(map[string]any)["locRange"].(map[string]any)
+	assert.Equal(t, "main.jsonnet", locRange["fileName"])
+	assert.Equal(t, map[string]any{"line": float64(1), "column": float64(1)}, locRange["begin"])
+}
+
+func TestUnmarshalNodeLegacyFieldKeys(t *testing.T) {
+	node, err := UnmarshalNode([]byte(`{"__kind__": "Object", "fields": [{"__kind__": "ObjectField", "kind": 1, "Hide": 0, "SuperSugar": true, "id": "a", "expr2": {"__kind__": "Var", "id": "b"}}]}`))
+	require.NoError(t, err)
+
+	res, err := formatter.FormatNode(node, nil, formatter.DefaultOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "{ a+:: b }\n", res)
+}
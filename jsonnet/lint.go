@@ -0,0 +1,314 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/google/go-jsonnet/toolutils"
+)
+
+type Diagnostic struct {
+	Rule     string        `json:"rule"`
+	Severity Severity      `json:"severity"`
+	Message  string        `json:"message"`
+	LocRange LocationRange `json:"locRange"`
+}
+
+type lintRule struct {
+	defaults LintRuleConfig
+	check    func(l *linter, config LintRuleConfig)
+}
+
+var lintRules = map[string]lintRule{
+	"unused-import": {
+		defaults: LintRuleConfig{Enabled: true, Severity: SeverityWarning},
+		check:    (*linter).checkUnusedImports,
+	},
+	"ext-var": {
+		defaults: LintRuleConfig{Enabled: true, Severity: SeverityWarning},
+		check:    (*linter).checkExtVars,
+	},
+	"deep-self-chain": {
+		defaults: LintRuleConfig{Enabled: true, Severity: SeverityWarning, MaxDepth: 3},
+		check:    (*linter).checkSelfChains,
+	},
+	"trailing-newline": {
+		defaults: LintRuleConfig{Enabled: true, Severity: SeverityWarning},
+		check:    (*linter).checkTrailingNewline,
+	},
+	"quoted-field-duplicate": {
+		defaults: LintRuleConfig{Enabled: true, Severity: SeverityError},
+		check:    (*linter).checkQuotedFieldDuplicates,
+	},
+}
+
+func Lint(filename string, code string, config LintConfig) (any, error) {
+	l := linter{
+		filename:    filename,
+		code:        code,
+		parents:     make(map[ast.Node]ast.Node),
+		diagnostics: make([]Diagnostic, 0),
+	}
+	node, _, err := formatter.SnippetToRawAST(filename, code)
+	if err != nil {
+		if !l.duplicateField(err, config) {
+			return nil, err
+		}
+	} else {
+		l.scopes = ResolveScopes(node, code)
+		l.collect(node, nil)
+		l.check(config)
+	}
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		return locationLess(l.diagnostics[i].LocRange.Begin, l.diagnostics[j].LocRange.Begin)
+	})
+	b, err := json.Marshal(l.diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0)
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, err
+	}
+	compact(res)
+	return res, nil
+}
+
+type linter struct {
+	filename    string
+	code        string
+	scopes      *Scopes
+	nodes       []ast.Node
+	parents     map[ast.Node]ast.Node
+	rule        string
+	severity    Severity
+	diagnostics []Diagnostic
+}
+
+func (l *linter) check(config LintConfig) {
+	ids := make([]string, 0, len(config))
+	for id := range config {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !config[id].Enabled {
+			continue
+		}
+		l.rule = id
+		l.severity = config[id].Severity
+		lintRules[id].check(l, config[id])
+	}
+}
+
+// duplicateField reports the parser's rejection of a field that is defined
+// twice with literal names, as in { a: 1, 'a': 2 }, as a quoted-field-duplicate
+// diagnostic. There is no AST to run the other rules on in that case.
+func (l *linter) duplicateField(err error, config LintConfig) bool {
+	var staticErr StaticError
+	if !errors.As(err, &staticErr) || !config["quoted-field-duplicate"].Enabled {
+		return false
+	}
+	name, ok := strings.CutPrefix(NewParseError(staticErr).Message, "Duplicate field: ")
+	if !ok {
+		return false
+	}
+	l.rule = "quoted-field-duplicate"
+	l.severity = config[l.rule].Severity
+	l.report(staticErr.Loc(), "field %q is already defined", name)
+	return true
+}
+
+func (l *linter) collect(node ast.Node, parent ast.Node) {
+	if node == nil {
+		return
+	}
+	if _, ok := l.parents[node]; ok {
+		return
+	}
+	l.parents[node] = parent
+	l.nodes = append(l.nodes, node)
+	for _, child := range toolutils.Children(node) {
+		l.collect(child, node)
+	}
+}
+
+func (l *linter) report(locRange ast.LocationRange, format string, args ...any) {
+	locRange.File = nil
+	locRange.FileName = l.filename
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Rule:     l.rule,
+		Severity: l.severity,
+		Message:  fmt.Sprintf(format, args...),
+		LocRange: NewLocationRange(locRange),
+	})
+}
+
+func (l *linter) checkUnusedImports(config LintRuleConfig) {
+	for _, b := range l.scopes.Bindings {
+		if len(b.References) > 0 {
+			continue
+		}
+		var file *ast.LiteralString
+		switch body := b.Body.(type) {
+		case *ast.Import:
+			file = body.File
+		case *ast.ImportStr:
+			file = body.File
+		case *ast.ImportBin:
+			file = body.File
+		default:
+			continue
+		}
+		l.report(b.LocRange(), "%s imports %q but is never used", b.Name, file.Value)
+	}
+}
+
+func (l *linter) checkExtVars(config LintRuleConfig) {
+	for _, node := range l.nodes {
+		apply, ok := node.(*ast.Apply)
+		if !ok || !isStdMember(apply.Target, "extVar") {
+			continue
+		}
+		if len(apply.Arguments.Positional) == 1 {
+			if name, ok := apply.Arguments.Positional[0].Expr.(*ast.LiteralString); ok {
+				l.report(*apply.Loc(), "std.extVar reads the external variable %q", name.Value)
+				continue
+			}
+		}
+		l.report(*apply.Loc(), "std.extVar reads an external variable")
+	}
+}
+
+func isStdMember(node ast.Node, name string) bool {
+	index, ok := node.(*ast.Index)
+	if !ok {
+		return false
+	}
+	if v, ok := index.Target.(*ast.Var); !ok || v.Id != "std" {
+		return false
+	}
+	if index.Id != nil {
+		return string(*index.Id) == name
+	}
+	s, ok := index.Index.(*ast.LiteralString)
+	return ok && s.Value == name
+}
+
+func (l *linter) checkSelfChains(config LintRuleConfig) {
+	for _, node := range l.nodes {
+		index, ok := node.(*ast.Index)
+		if !ok {
+			continue
+		}
+		if parent, ok := l.parents[node].(*ast.Index); ok && parent.Target == node {
+			continue
+		}
+		depth := 0
+		var root ast.Node = index
+		for {
+			i, ok := root.(*ast.Index)
+			if !ok {
+				break
+			}
+			depth++
+			root = i.Target
+		}
+		var rootName string
+		switch root.(type) {
+		case *ast.Self:
+			rootName = "self"
+		case *ast.Dollar:
+			rootName = "$"
+		case *ast.SuperIndex:
+			rootName = "super"
+			depth++
+		default:
+			continue
+		}
+		if depth > config.MaxDepth {
+			l.report(*index.Loc(), "%s chain is %d levels deep, more than %d", rootName, depth, config.MaxDepth)
+		}
+	}
+}
+
+func (l *linter) checkTrailingNewline(config LintRuleConfig) {
+	if l.code == "" || strings.HasSuffix(l.code, "\n") {
+		return
+	}
+	lines := strings.Split(l.code, "\n")
+	end := ast.Location{Line: len(lines), Column: utf8.RuneCountInString(lines[len(lines)-1]) + 1}
+	l.report(ast.LocationRange{Begin: end, End: end}, "file does not end with a newline")
+}
+
+// checkQuotedFieldDuplicates finds fields with the same literal name where at
+// least one of them is computed, as in { a: 1, ['a']: 2 }. The parser rejects
+// the other duplicates, which duplicateField reports instead.
+func (l *linter) checkQuotedFieldDuplicates(config LintRuleConfig) {
+	for _, node := range l.nodes {
+		object, ok := node.(*ast.Object)
+		if !ok {
+			continue
+		}
+		seen := make(map[string][]*ast.ObjectField)
+		for i := range object.Fields {
+			field := &object.Fields[i]
+			name, ok := literalFieldName(field)
+			if !ok {
+				continue
+			}
+			for _, first := range seen[name] {
+				if fieldQuoting(first) != fieldQuoting(field) {
+					l.report(field.LocRange, "field %q is already defined at %s with different quoting", name, first.LocRange.Begin.String())
+					break
+				}
+			}
+			seen[name] = append(seen[name], field)
+		}
+	}
+}
+
+// fieldQuoting describes how the name of a field with a literal name is
+// written.
+func fieldQuoting(field *ast.ObjectField) string {
+	if field.Kind == ast.ObjectFieldID {
+		return "id"
+	}
+	expr := field.Expr1
+	for {
+		parens, ok := expr.(*ast.Parens)
+		if !ok {
+			break
+		}
+		expr = parens.Inner
+	}
+	s := expr.(*ast.LiteralString)
+	return fmt.Sprintf("%d/%d", field.Kind, s.Kind)
+}
+
+func literalFieldName(field *ast.ObjectField) (string, bool) {
+	switch field.Kind {
+	case ast.ObjectFieldID:
+		return string(*field.Id), true
+	case ast.ObjectFieldStr, ast.ObjectFieldExpr:
+		expr := field.Expr1
+		for {
+			parens, ok := expr.(*ast.Parens)
+			if !ok {
+				break
+			}
+			expr = parens.Inner
+		}
+		if s, ok := expr.(*ast.LiteralString); ok {
+			return s.Value, true
+		}
+	}
+	return "", false
+}
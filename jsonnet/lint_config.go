@@ -0,0 +1,98 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	switch Severity(name) {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		*s = Severity(name)
+		return nil
+	default:
+		return fmt.Errorf("unknown severity: %s", name)
+	}
+}
+
+type LintRuleConfig struct {
+	Enabled  bool     `json:"enabled"`
+	Severity Severity `json:"severity"`
+	MaxDepth int      `json:"maxDepth,omitempty"`
+}
+
+func (c *LintRuleConfig) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if json.Unmarshal(data, &enabled) == nil {
+		c.Enabled = enabled
+		return nil
+	}
+	type rawConfig LintRuleConfig
+	raw := rawConfig(*c)
+	raw.Enabled = true
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return err
+	}
+	*c = LintRuleConfig(raw)
+	return nil
+}
+
+type LintConfig map[string]LintRuleConfig
+
+func DefaultLintConfig() LintConfig {
+	config := LintConfig{}
+	for id, rule := range lintRules {
+		config[id] = rule.defaults
+	}
+	return config
+}
+
+func NewLintConfig(val any) (LintConfig, error) {
+	config := DefaultLintConfig()
+	if val == nil {
+		return config, nil
+	}
+	rawConfig, ok := val.(map[string]any)
+	if !ok {
+		return config, fmt.Errorf("config must be an object")
+	}
+	ids := make([]string, 0, len(rawConfig))
+	for id := range rawConfig {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		rule, ok := config[id]
+		if !ok {
+			return config, fmt.Errorf("unknown lint rule: %s", id)
+		}
+		b, err := json.Marshal(rawConfig[id])
+		if err != nil {
+			return config, err
+		}
+		err = json.Unmarshal(b, &rule)
+		if err != nil {
+			return config, fmt.Errorf("invalid config for %s: %w", id, err)
+		}
+		config[id] = rule
+	}
+	return config, nil
+}
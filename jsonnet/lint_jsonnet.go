@@ -0,0 +1,43 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func LintJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "lintJsonnet",
+		Params: ast.Identifiers{"code", "config", "filename"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 1 || len(input) > 3 {
+				return nil, fmt.Errorf("code must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			var rawConfig any
+			if len(input) >= 2 {
+				rawConfig = input[1]
+			}
+			config, err := NewLintConfig(rawConfig)
+			if err != nil {
+				return nil, err
+			}
+			filename := DefaultFilename
+			if len(input) == 3 && input[2] != nil {
+				filename, ok = input[2].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			out, err := Lint(filename, code, config)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package jsonnet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		config   map[string]any
+		expected []string
+	}{
+		{
+			name:     "clean",
+			code:     "local a = import 'a.libsonnet';\n{ b: a.b, c: self.b.c.d }\n",
+			expected: []string{},
+		},
+		{
+			name:     "unused import",
+			code:     "local a = import 'a.libsonnet', b = importstr 'b.txt', c = 1;\n{ d: importbin 'd.bin', local e = import 'e.libsonnet' }\n",
+			expected: []string{"unused-import warning 1:7", "unused-import warning 1:33", "unused-import warning 2:31"},
+		},
+		{
+			name:     "ext var",
+			code:     "[std.extVar('a'), std['extVar'](name), std.length('a')]\n",
+			expected: []string{"ext-var warning 1:2", "ext-var warning 1:19"},
+		},
+		{
+			name:     "deep self chain",
+			code:     "{ a: self.b.c.d.e, b: $.b.c.d.e.f, c: super.b.c.d.e, d: self.b.c.d }\n",
+			expected: []string{"deep-self-chain warning 1:6", "deep-self-chain warning 1:23", "deep-self-chain warning 1:39"},
+		},
+		{
+			name:     "deep self chain max depth",
+			code:     "{ a: self.b.c }\n",
+			config:   map[string]any{"deep-self-chain": map[string]any{"maxDepth": 1}},
+			expected: []string{"deep-self-chain warning 1:6"},
+		},
+		{
+			name:     "missing trailing newline",
+			code:     "{\n  a: 1,\n}",
+			expected: []string{"trailing-newline warning 3:2"},
+		},
+		{
+			name:     "quoted field duplicate",
+			code:     "{ a: 1, ['a']: 2, [('b')]: 3, \"b\": 4 }\n",
+			expected: []string{"quoted-field-duplicate error 1:9", "quoted-field-duplicate error 1:31"},
+		},
+		{
+			name:     "quoted duplicate of a plain field",
+			code:     "local x = import 'x.libsonnet';\n{ a: 1, 'a': 2 }\n",
+			expected: []string{"quoted-field-duplicate error 2:9"},
+		},
+		{
+			name:     "different string kinds",
+			code:     "{ ['a']: 1, [\"a\"]: 2 }\n",
+			expected: []string{"quoted-field-duplicate error 1:13"},
+		},
+		{
+			name:     "same quoting",
+			code:     "{ ['a']: 1, ['a']: 2, [('b')]: 3, ['b']: 4 }\n",
+			expected: []string{},
+		},
+		{
+			name:     "disabled rule",
+			code:     "std.extVar('a')",
+			config:   map[string]any{"ext-var": false},
+			expected: []string{"trailing-newline warning 1:16"},
+		},
+		{
+			name:     "severity override",
+			code:     "std.extVar('a')\n",
+			config:   map[string]any{"ext-var": map[string]any{"severity": "error"}},
+			expected: []string{"ext-var error 1:1"},
+		},
+		{
+			name:     "explicitly enabled",
+			code:     "std.extVar('a')\n",
+			config:   map[string]any{"ext-var": true},
+			expected: []string{"ext-var warning 1:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LintJsonnet().Func([]any{tt.code, tt.config})
+			require.NoError(t, err)
+
+			diagnostics := []string{}
+			for _, elem := range result.([]any) {
+				d := elem.(map[string]any)
+				begin := d["locRange"].(map[string]any)["begin"].(map[string]any)
+				diagnostics = append(diagnostics, fmt.Sprintf("%s %s %v:%v", d["rule"], d["severity"], begin["line"], begin["column"]))
+			}
+			assert.Equal(t, tt.expected, diagnostics)
+		})
+	}
+}
+
+func TestLintJsonnetDiagnostic(t *testing.T) {
+	result, err := LintJsonnet().Func([]any{"std.extVar('env')\n", nil, "config.jsonnet"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{map[string]any{
+		"rule":     "ext-var",
+		"severity": "warning",
+		"message":  "std.extVar reads the external variable \"env\"",
+		"locRange": map[string]any{
+			"fileName": "config.jsonnet",
+			"begin":    map[string]any{"line": float64(1), "column": float64(1)},
+			"end":      map[string]any{"line": float64(1), "column": float64(18)},
+		},
+	}}, result)
+}
+
+func TestLintJsonnetClean(t *testing.T) {
+	result, err := LintJsonnet().Func([]any{"{ a: 1 }\n"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{}, result)
+}
+
+func TestLintJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code must be provided",
+		},
+		{
+			name:     "non-string code",
+			input:    []any{123},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-object config",
+			input:    []any{"null", []any{}},
+			expected: "config must be an object",
+		},
+		{
+			name:     "unknown rule",
+			input:    []any{"null", map[string]any{"foo": true}},
+			expected: "unknown lint rule: foo",
+		},
+		{
+			name:     "unknown severity",
+			input:    []any{"null", map[string]any{"ext-var": map[string]any{"severity": "fatal"}}},
+			expected: "invalid config for ext-var: unknown severity: fatal",
+		},
+		{
+			name:     "unknown rule option",
+			input:    []any{"null", map[string]any{"ext-var": map[string]any{"foo": 1}}},
+			expected: "invalid config for ext-var",
+		},
+		{
+			name:     "non-string filename",
+			input:    []any{"null", nil, 1},
+			expected: "filename must be a string",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"{"},
+			expected: "main.jsonnet:1:2",
+		},
+		{
+			name:     "duplicate field with the rule disabled",
+			input:    []any{"{ a: 1, 'a': 2 }", map[string]any{"quoted-field-duplicate": false}},
+			expected: "main.jsonnet:1:9-12 Duplicate field: a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LintJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
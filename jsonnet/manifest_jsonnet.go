@@ -9,12 +9,20 @@ import (
 func ManifestJsonnet() jsonnet.NativeFunction {
 	return jsonnet.NativeFunction{
 		Name:   "manifestJsonnet",
-		Params: ast.Identifiers{"jsonnet"},
+		Params: ast.Identifiers{"jsonnet", "options"},
 		Func: func(input []any) (any, error) {
-			if len(input) != 1 {
+			if len(input) < 1 || len(input) > 2 {
 				return nil, fmt.Errorf("jsonnet must be provided")
 			}
-			out, err := Manifest(input[0])
+			var rawOptions any
+			if len(input) == 2 {
+				rawOptions = input[1]
+			}
+			options, err := NewFormatOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			out, err := Manifest(input[0], options)
 			if err != nil {
 				return nil, err
 			}
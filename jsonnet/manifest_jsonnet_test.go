@@ -19,9 +19,24 @@ func TestManifestJsonnetErrors(t *testing.T) {
 		},
 		{
 			name:     "too many arguments",
-			input:    []any{"jsonnet", "extra"},
+			input:    []any{"jsonnet", nil, "extra"},
 			expected: "jsonnet must be provided",
 		},
+		{
+			name:     "unsupported node",
+			input:    []any{map[string]any{"__kind__": "Parens", "inner": map[string]any{"__kind__": "Bogus"}}},
+			expected: "unsupported node kind \"Bogus\" at $.inner",
+		},
+		{
+			name:     "non-object options",
+			input:    []any{map[string]any{"__kind__": "LiteralNull"}, "options"},
+			expected: "options must be an object",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{map[string]any{"__kind__": "LiteralNull"}, map[string]any{"foo": true}},
+			expected: "unknown field \"foo\"",
+		},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseJsonnetErrors(t *testing.T) {
@@ -19,7 +20,7 @@ func TestParseJsonnetErrors(t *testing.T) {
 		},
 		{
 			name:     "too many arguments",
-			input:    []any{"jsonnet", "extra"},
+			input:    []any{"jsonnet", nil, "main.jsonnet", "extra"},
 			expected: "jsonnet must be provided",
 		},
 		{
@@ -32,6 +33,26 @@ func TestParseJsonnetErrors(t *testing.T) {
 			input:    []any{nil},
 			expected: "jsonnet must be a string",
 		},
+		{
+			name:     "non-object options",
+			input:    []any{"null", true},
+			expected: "options must be an object",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{"null", map[string]any{"minify": true}},
+			expected: "unknown field \"minify\"",
+		},
+		{
+			name:     "non-string filename",
+			input:    []any{"null", nil, 123},
+			expected: "filename must be a string",
+		},
+		{
+			name:     "mistyped option",
+			input:    []any{"null", map[string]any{"compact": "yes"}},
+			expected: "invalid options",
+		},
 	}
 
 	for _, tt := range tests {
@@ -44,3 +65,19 @@ func TestParseJsonnetErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseJsonnetFilename(t *testing.T) {
+	result, err := ParseJsonnet().Func([]any{"{ a: 1 }", nil, "lib/config.libsonnet"})
+	require.NoError(t, err)
+
+	node := result.(map[string]any)
+	locRange := node["locRange"].(map[string]any)
+	assert.Equal(t, "lib/config.libsonnet", locRange["fileName"])
+	assert.Equal(t, "lib/config.libsonnet", locRange["file"].(map[string]any)["diagnosticFileName"])
+
+	field := node["fields"].([]any)[0].(map[string]any)
+	assert.Equal(t, "lib/config.libsonnet", field["locRange"].(map[string]any)["fileName"])
+
+	_, err = ParseJsonnet().Func([]any{"{ a: }", nil, "lib/config.libsonnet"})
+	assert.ErrorContains(t, err, "lib/config.libsonnet:1:6-7")
+}
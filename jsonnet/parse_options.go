@@ -0,0 +1,36 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type ParseOptions struct {
+	Compact bool `json:"compact"`
+}
+
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{}
+}
+
+func NewParseOptions(val any) (ParseOptions, error) {
+	options := DefaultParseOptions()
+	if val == nil {
+		return options, nil
+	}
+	if _, ok := val.(map[string]any); !ok {
+		return options, fmt.Errorf("options must be an object")
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return options, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&options)
+	if err != nil {
+		return options, fmt.Errorf("invalid options: %w", err)
+	}
+	return options, nil
+}
@@ -7,8 +7,25 @@ import (
 
 func Plugin() *jpoet.Plugin {
 	return jpoet.NewPlugin("jsonnet", []jsonnet.NativeFunction{
+		AnalyzeJsonnet(),
+		DesugarJsonnet(),
+		DiffJsonnet(),
+		EqualJsonnet(),
+		EvalJsonnet(),
+		EvaluateJsonnet(),
 		FormatJsonnet(),
+		ImportGraphJsonnet(),
+		ImportsJsonnet(),
+		JsonToJsonnetJsonnet(),
+		LintJsonnet(),
 		ManifestJsonnet(),
 		ParseJsonnet(),
+		QueryJsonnet(),
+		RenameJsonnet(),
+		TemplateJsonnet(),
+		TransformJsonnet(),
+		TryParseJsonnet(),
+		ValueToAstJsonnet(),
+		YamlToJsonnetJsonnet(),
 	})
 }
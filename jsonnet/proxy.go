@@ -0,0 +1,73 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+type UnsupportedNodeError struct {
+	Kind string
+	Path []string
+}
+
+func (e *UnsupportedNodeError) Error() string {
+	if e.Kind == "" {
+		return fmt.Sprintf("missing __kind__ at %s", e.JSONPath())
+	}
+	return fmt.Sprintf("unsupported node kind %q at %s", e.Kind, e.JSONPath())
+}
+
+func (e *UnsupportedNodeError) JSONPath() string {
+	return "$" + strings.Join(e.Path, "")
+}
+
+func withPath(err error, segment string) error {
+	var unsupported *UnsupportedNodeError
+	if errors.As(err, &unsupported) {
+		unsupported.Path = append([]string{segment}, unsupported.Path...)
+		return unsupported
+	}
+	return err
+}
+
+// marshalField marshals a child of a proxy, adding segment to the path of an
+// unsupported node below it.
+func marshalField(v any, segment string) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, withPath(err, segment)
+	}
+	return b, nil
+}
+
+// unmarshalField unmarshals a child of a proxy, leaving v untouched when the
+// child is missing or null.
+func unmarshalField(data json.RawMessage, v any, segment string) error {
+	if isNull(data) {
+		return nil
+	}
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return withPath(err, segment)
+	}
+	return nil
+}
+
+func unmarshalNode(data json.RawMessage, segment string) (ast.Node, error) {
+	var n Node
+	err := unmarshalField(data, &n, segment)
+	if err != nil {
+		return nil, err
+	}
+	return n.Node, nil
+}
+
+func isNull(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) == 0 || string(data) == "null"
+}
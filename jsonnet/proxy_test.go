@@ -0,0 +1,109 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unknownNode struct {
+	ast.NodeBase
+}
+
+func TestMarshalNodeUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		node ast.Node
+		path string
+	}{
+		{
+			name: "root",
+			node: &unknownNode{},
+			path: "$",
+		},
+		{
+			name: "apply target",
+			node: &ast.Apply{Target: &unknownNode{}},
+			path: "$.target",
+		},
+		{
+			name: "local bind",
+			node: &ast.Local{
+				Binds: ast.LocalBinds{
+					{Variable: "a", Body: &ast.LiteralNull{}},
+					{Variable: "b", Body: &ast.Parens{Inner: &unknownNode{}}},
+				},
+				Body: &ast.Var{Id: "a"},
+			},
+			path: "$.binds[1].body.inner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarshalNode(tt.node)
+
+			var unsupported *UnsupportedNodeError
+			require.ErrorAs(t, err, &unsupported)
+			assert.Equal(t, "*jsonnet.unknownNode", unsupported.Kind)
+			assert.Equal(t, tt.path, unsupported.JSONPath())
+			assert.Equal(t, err, unsupported)
+		})
+	}
+}
+
+func TestUnmarshalNodeUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		kind string
+		path string
+	}{
+		{
+			name: "root",
+			json: `{"__kind__": "Bogus"}`,
+			kind: "Bogus",
+			path: "$",
+		},
+		{
+			name: "missing kind",
+			json: `{"value": true}`,
+			kind: "",
+			path: "$",
+		},
+		{
+			name: "local bind",
+			json: `{"__kind__": "Local", "binds": [{"__kind__": "LocalBind", "variable": "a", "body": {"__kind__": "Bogus"}}], "body": {"__kind__": "Var", "id": "a"}}`,
+			kind: "Bogus",
+			path: "$.binds[0].body",
+		},
+		{
+			name: "apply argument",
+			json: `{"__kind__": "Apply", "target": {"__kind__": "Var", "id": "f"}, "arguments": {"positional": [{"expr": {"__kind__": "LiteralNull"}}, {"expr": {"id": "a"}}]}}`,
+			kind: "",
+			path: "$.arguments.positional[1].expr",
+		},
+		{
+			name: "object field",
+			json: `{"__kind__": "Object", "fields": [{"__kind__": "ObjectField", "kind": 1, "id": "a", "expr2": {"__kind__": "ObjectField"}}]}`,
+			kind: "ObjectField",
+			path: "$.fields[0].expr2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalNode([]byte(tt.json))
+
+			var unsupported *UnsupportedNodeError
+			require.ErrorAs(t, err, &unsupported)
+			assert.Equal(t, tt.kind, unsupported.Kind)
+			assert.Equal(t, tt.path, unsupported.JSONPath())
+			if tt.kind == "" {
+				assert.Equal(t, "missing __kind__ at "+tt.path, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet/formatter"
+)
+
+type Selector []SelectorStep
+
+type SelectorStep struct {
+	Combinator byte
+	Kind       string
+	Attributes []AttributeMatcher
+}
+
+type AttributeMatcher struct {
+	Path     []string
+	Value    string
+	HasValue bool
+}
+
+func ParseSelector(selector string) (Selector, error) {
+	p := selectorParser{input: selector}
+	return p.parse()
+}
+
+type selectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectorParser) parse() (Selector, error) {
+	var res Selector
+	combinator := byte(0)
+	for {
+		hadSpace := p.skipSpace()
+		if p.pos >= len(p.input) {
+			break
+		}
+		if p.input[p.pos] == '>' {
+			if len(res) == 0 || combinator != 0 {
+				return nil, p.errorf("unexpected '>'")
+			}
+			combinator = '>'
+			p.pos++
+			continue
+		}
+		if len(res) > 0 && combinator == 0 {
+			if !hadSpace {
+				return nil, p.errorf("unexpected %q", p.input[p.pos])
+			}
+			combinator = ' '
+		}
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		step.Combinator = combinator
+		res = append(res, step)
+		combinator = 0
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("selector must not be empty")
+	}
+	if combinator != 0 {
+		return nil, p.errorf("selector must not end with a combinator")
+	}
+	return res, nil
+}
+
+func (p *selectorParser) parseStep() (SelectorStep, error) {
+	step := SelectorStep{}
+	if p.input[p.pos] == '*' {
+		p.pos++
+	} else {
+		step.Kind = p.parseIdentifier()
+		if step.Kind == "" {
+			return step, p.errorf("expected node kind")
+		}
+	}
+	for p.pos < len(p.input) && p.input[p.pos] == '[' {
+		p.pos++
+		attribute, err := p.parseAttribute()
+		if err != nil {
+			return step, err
+		}
+		step.Attributes = append(step.Attributes, attribute)
+	}
+	return step, nil
+}
+
+func (p *selectorParser) parseAttribute() (AttributeMatcher, error) {
+	attribute := AttributeMatcher{}
+	for {
+		p.skipSpace()
+		name := p.parseIdentifier()
+		if name == "" {
+			return attribute, p.errorf("expected attribute name")
+		}
+		attribute.Path = append(attribute.Path, name)
+		if p.pos < len(p.input) && p.input[p.pos] == '.' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '=' {
+		p.pos++
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return attribute, err
+		}
+		attribute.Value = value
+		attribute.HasValue = true
+		p.skipSpace()
+	}
+	if p.pos >= len(p.input) || p.input[p.pos] != ']' {
+		return attribute, p.errorf("expected ']'")
+	}
+	p.pos++
+	return attribute, nil
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	if p.pos < len(p.input) && (p.input[p.pos] == '\'' || p.input[p.pos] == '"') {
+		quote := p.input[p.pos]
+		end := strings.IndexByte(p.input[p.pos+1:], quote)
+		if end < 0 {
+			return "", p.errorf("unterminated value")
+		}
+		value := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == ']' && depth == 0 {
+			break
+		}
+		switch c {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		}
+		p.pos++
+	}
+	value := strings.TrimSpace(p.input[start:p.pos])
+	if value == "" {
+		return "", p.errorf("expected attribute value")
+	}
+	return value, nil
+}
+
+func (p *selectorParser) parseIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || p.pos > start && c >= '0' && c <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\n\r", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid selector at column %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+type QueryMatch struct {
+	Path     string `json:"path"`
+	Node     any    `json:"node"`
+	LocRange any    `json:"locRange"`
+}
+
+func Query(root any, selector string) (any, error) {
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if code, ok := root.(string); ok {
+		root, err = Parse(DefaultFilename, code, ParseOptions{Compact: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := root.(map[string]any); !ok {
+		return nil, fmt.Errorf("jsonnet must be a string or an AST")
+	}
+	matches, err := QueryMatches(root, parsed)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(matches)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0)
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func QueryMatches(root any, selector Selector) ([]QueryMatch, error) {
+	q := query{selector: selector, matches: make([]QueryMatch, 0)}
+	err := q.walk(root, "$", nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(q.matches, func(i, j int) bool {
+		return locationBefore(q.matches[i].LocRange, q.matches[j].LocRange)
+	})
+	return q.matches, nil
+}
+
+type query struct {
+	selector Selector
+	matches  []QueryMatch
+}
+
+func (q *query) walk(val any, path string, ancestors []map[string]any) error {
+	switch v := val.(type) {
+	case map[string]any:
+		if _, ok := v["__kind__"].(string); ok {
+			matched, err := q.match(v, ancestors, len(q.selector)-1)
+			if err != nil {
+				return err
+			}
+			if matched {
+				q.matches = append(q.matches, QueryMatch{
+					Path:     path,
+					Node:     v,
+					LocRange: nodeLocation(v),
+				})
+			}
+			ancestors = append(ancestors, v)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "locRange" {
+				continue
+			}
+			err := q.walk(v[key], path+"."+key, ancestors)
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, elem := range v {
+			err := q.walk(elem, fmt.Sprintf("%s[%d]", path, i), ancestors)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (q *query) match(node map[string]any, ancestors []map[string]any, index int) (bool, error) {
+	step := q.selector[index]
+	matched, err := step.matches(node)
+	if err != nil || !matched {
+		return false, err
+	}
+	if index == 0 {
+		return true, nil
+	}
+	switch step.Combinator {
+	case '>':
+		if len(ancestors) == 0 {
+			return false, nil
+		}
+		return q.match(ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1], index-1)
+	default:
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			matched, err := q.match(ancestors[i], ancestors[:i], index-1)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	}
+}
+
+func (s SelectorStep) matches(node map[string]any) (bool, error) {
+	if s.Kind != "" && node["__kind__"] != s.Kind {
+		return false, nil
+	}
+	for _, attribute := range s.Attributes {
+		matched, err := attribute.matches(node)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (a AttributeMatcher) matches(node map[string]any) (bool, error) {
+	var val any = node
+	for _, key := range a.Path {
+		m, ok := val.(map[string]any)
+		if !ok {
+			return false, nil
+		}
+		val, ok = m[key]
+		if !ok {
+			return false, nil
+		}
+	}
+	if !a.HasValue {
+		return val != nil, nil
+	}
+	text, err := attributeText(val)
+	if err != nil {
+		return false, err
+	}
+	return text == a.Value, nil
+}
+
+func attributeText(val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return v, nil
+	case map[string]any:
+		if value, ok := v["value"].(string); ok && v["__kind__"] == "LiteralString" {
+			return value, nil
+		}
+		if _, ok := v["__kind__"]; ok {
+			options := formatter.DefaultOptions()
+			options.StripComments = true
+			text, err := Manifest(v, options)
+			if err == nil {
+				return strings.TrimSpace(text), nil
+			}
+		}
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func nodeLocation(node map[string]any) any {
+	if locRange, ok := node["locRange"].(map[string]any); ok && locationSet(locRange) {
+		res := make(map[string]any, len(locRange))
+		for key, val := range locRange {
+			if key != "file" {
+				res[key] = val
+			}
+		}
+		return res
+	}
+	for _, key := range []string{"body", "expr", "arg", "expr2", "index"} {
+		if child, ok := node[key].(map[string]any); ok {
+			if locRange := nodeLocation(child); locRange != nil {
+				return locRange
+			}
+		}
+	}
+	return nil
+}
+
+func locationSet(locRange map[string]any) bool {
+	begin, _ := locRange["begin"].(map[string]any)
+	line, _ := begin["line"].(float64)
+	return line > 0
+}
+
+func locationBefore(a, b any) bool {
+	aBegin := locationBegin(a)
+	bBegin := locationBegin(b)
+	if aBegin[0] != bBegin[0] {
+		return aBegin[0] < bBegin[0]
+	}
+	return aBegin[1] < bBegin[1]
+}
+
+func locationBegin(locRange any) [2]float64 {
+	m, _ := locRange.(map[string]any)
+	begin, _ := m["begin"].(map[string]any)
+	line, _ := begin["line"].(float64)
+	column, _ := begin["column"].(float64)
+	return [2]float64{line, column}
+}
@@ -0,0 +1,28 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func QueryJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "queryJsonnet",
+		Params: ast.Identifiers{"jsonnet", "selector"},
+		Func: func(input []any) (any, error) {
+			if len(input) != 2 {
+				return nil, fmt.Errorf("jsonnet and selector must be provided")
+			}
+			selector, ok := input[1].(string)
+			if !ok {
+				return nil, fmt.Errorf("selector must be a string")
+			}
+			out, err := Query(input[0], selector)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
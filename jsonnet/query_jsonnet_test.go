@@ -0,0 +1,160 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryJsonnet(t *testing.T) {
+	code := "local a = { name: std.map(function(x) x, [1]), b: import 'foo.libsonnet' };\na { name: 2 }"
+	tests := []struct {
+		name     string
+		selector string
+		expected []string
+	}{
+		{
+			name:     "kind",
+			selector: "Import",
+			expected: []string{"$.binds[0].body.fields[1].expr2"},
+		},
+		{
+			name:     "node attribute",
+			selector: "Apply[target=std.map]",
+			expected: []string{"$.binds[0].body.fields[0].expr2"},
+		},
+		{
+			name:     "string attribute",
+			selector: "ObjectField[id=name]",
+			expected: []string{"$.binds[0].body.fields[0]", "$.body.right.fields[0]"},
+		},
+		{
+			name:     "quoted attribute",
+			selector: "Import[file='foo.libsonnet']",
+			expected: []string{"$.binds[0].body.fields[1].expr2"},
+		},
+		{
+			name:     "nested attribute",
+			selector: "Apply[target.target.id=std][target.id=map]",
+			expected: []string{"$.binds[0].body.fields[0].expr2"},
+		},
+		{
+			name:     "attribute presence",
+			selector: "Function[parameters]",
+			expected: []string{"$.binds[0].body.fields[0].expr2.arguments.positional[0].expr"},
+		},
+		{
+			name:     "descendant",
+			selector: "ApplyBrace ObjectField",
+			expected: []string{"$.body.right.fields[0]"},
+		},
+		{
+			name:     "child",
+			selector: "LocalBind > Object > ObjectField[id=b]",
+			expected: []string{"$.binds[0].body.fields[1]"},
+		},
+		{
+			name:     "wildcard",
+			selector: "ObjectField[id=b] > *",
+			expected: []string{"$.binds[0].body.fields[1].expr2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := QueryJsonnet().Func([]any{code, tt.selector})
+			require.NoError(t, err)
+
+			paths := []string{}
+			for _, match := range result.([]any) {
+				paths = append(paths, match.(map[string]any)["path"].(string))
+			}
+			assert.Equal(t, tt.expected, paths)
+		})
+	}
+}
+
+func TestQueryJsonnetNoMatch(t *testing.T) {
+	result, err := QueryJsonnet().Func([]any{"[1, 2]", "Error"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{}, result)
+}
+
+func TestQueryJsonnetLocation(t *testing.T) {
+	result, err := QueryJsonnet().Func([]any{"{\n  a: import 'a.libsonnet',\n}", "Import"})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	match := result.([]any)[0].(map[string]any)
+	assert.Equal(t, "Import", match["node"].(map[string]any)["__kind__"])
+	locRange := match["locRange"].(map[string]any)
+	assert.Equal(t, "main.jsonnet", locRange["fileName"])
+	assert.Equal(t, map[string]any{"line": float64(2), "column": float64(6)}, locRange["begin"])
+	assert.Equal(t, map[string]any{"line": float64(2), "column": float64(26)}, locRange["end"])
+}
+
+func TestQueryJsonnetAst(t *testing.T) {
+	node, err := Parse(DefaultFilename, "[std.map(f, x), std.filter(f, x)]", DefaultParseOptions())
+	require.NoError(t, err)
+
+	result, err := QueryJsonnet().Func([]any{node, "Apply[target=std.filter]"})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	match := result.([]any)[0].(map[string]any)
+	assert.Equal(t, "$.elements[1].expr", match["path"])
+}
+
+func TestQueryJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "jsonnet and selector must be provided",
+		},
+		{
+			name:     "non-string selector",
+			input:    []any{"null", 123},
+			expected: "selector must be a string",
+		},
+		{
+			name:     "invalid jsonnet",
+			input:    []any{123, "Import"},
+			expected: "jsonnet must be a string or an AST",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"{", "Import"},
+			expected: "main.jsonnet:1:2",
+		},
+		{
+			name:     "empty selector",
+			input:    []any{"null", " "},
+			expected: "selector must not be empty",
+		},
+		{
+			name:     "unterminated attribute",
+			input:    []any{"null", "Apply[target"},
+			expected: "invalid selector at column 13: expected ']'",
+		},
+		{
+			name:     "dangling combinator",
+			input:    []any{"null", "Object >"},
+			expected: "selector must not end with a combinator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := QueryJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
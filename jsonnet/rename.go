@@ -0,0 +1,152 @@
+package jsonnet
+
+import (
+	"fmt"
+
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+)
+
+func Rename(filename string, code string, loc ast.Location, newName string) (string, error) {
+	node, finalFodder, err := formatter.SnippetToRawAST(filename, code)
+	if err != nil {
+		return "", err
+	}
+	scopes := ResolveScopes(node, code)
+	b, err := scopes.bindingAt(loc)
+	if err != nil {
+		return "", err
+	}
+	if !isIdentifier(newName) {
+		return "", fmt.Errorf("invalid identifier: %s", newName)
+	}
+	name := ast.Identifier(newName)
+	if name != b.Name {
+		err = scopes.checkRename(b, name)
+		if err != nil {
+			return "", err
+		}
+		args, err := scopes.namedArguments(b, name)
+		if err != nil {
+			return "", err
+		}
+		b.rename(name)
+		for _, ref := range b.References {
+			ref.Id = name
+		}
+		for _, arg := range args {
+			arg.Name = name
+		}
+	}
+	return formatter.FormatNode(node, finalFodder, formatter.DefaultOptions())
+}
+
+func (s *Scopes) bindingAt(loc ast.Location) (*Binding, error) {
+	for _, b := range s.Bindings {
+		if b.Loc.Line > 0 && locationWithin(loc, b.LocRange()) {
+			return b, nil
+		}
+	}
+	for _, v := range s.Vars {
+		if !locationWithin(loc, v.LocRange) {
+			continue
+		}
+		b := s.Resolve(v)
+		if b == nil {
+			return nil, fmt.Errorf("%s at %s is not bound in this file", v.Id, loc.String())
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("no binding at %s", loc.String())
+}
+
+func (s *Scopes) checkRename(b *Binding, name ast.Identifier) error {
+	if existing, ok := b.scope.bindings[name]; ok {
+		return fmt.Errorf("cannot rename %s to %s: %s is already declared at %s", b.Name, name, name, existing.Loc.String())
+	}
+	for _, ref := range b.References {
+		if other := lookupAfterRename(s.varScopes[ref], name, b); other != b {
+			return fmt.Errorf("cannot rename %s to %s: the reference at %s would be captured by %s declared at %s", b.Name, name, ref.LocRange.Begin.String(), name, other.Loc.String())
+		}
+	}
+	for _, v := range s.Vars {
+		if v.Id != name {
+			continue
+		}
+		if lookupAfterRename(s.varScopes[v], name, b) == b {
+			return fmt.Errorf("cannot rename %s to %s: it would capture the reference to %s at %s", b.Name, name, name, v.LocRange.Begin.String())
+		}
+	}
+	return nil
+}
+
+// namedArguments finds the named arguments that pass the parameter b. The
+// rename is refused when an argument of that name is passed to a function
+// that cannot be resolved, as it might be b.
+func (s *Scopes) namedArguments(b *Binding, name ast.Identifier) ([]*ast.NamedArgument, error) {
+	if b.owner == nil {
+		return nil, nil
+	}
+	var res []*ast.NamedArgument
+	for _, apply := range s.applies {
+		for i := range apply.Arguments.Named {
+			arg := &apply.Arguments.Named[i]
+			if arg.Name != b.Name {
+				continue
+			}
+			fun := s.calledFunction(apply)
+			if fun == nil {
+				return nil, fmt.Errorf("cannot rename %s to %s: the call at %s might pass it as a named argument", b.Name, name, apply.LocRange.Begin.String())
+			}
+			if fun != b.owner {
+				continue
+			}
+			for _, other := range apply.Arguments.Named {
+				if other.Name == name {
+					return nil, fmt.Errorf("cannot rename %s to %s: the call at %s already passes %s", b.Name, name, apply.LocRange.Begin.String(), name)
+				}
+			}
+			res = append(res, arg)
+		}
+	}
+	return res, nil
+}
+
+func (s *Scopes) calledFunction(apply *ast.Apply) *ast.Function {
+	target := apply.Target
+	for {
+		parens, ok := target.(*ast.Parens)
+		if !ok {
+			break
+		}
+		target = parens.Inner
+	}
+	switch t := target.(type) {
+	case *ast.Function:
+		return t
+	case *ast.Var:
+		if b := s.Resolve(t); b != nil {
+			return b.fun
+		}
+	}
+	return nil
+}
+
+func lookupAfterRename(s *scope, name ast.Identifier, renamed *Binding) *Binding {
+	for sc := s; sc != nil; sc = sc.parent {
+		if sc == renamed.scope {
+			return renamed
+		}
+		if b, ok := sc.bindings[name]; ok {
+			return b
+		}
+	}
+	return nil
+}
+
+func locationWithin(loc ast.Location, locRange ast.LocationRange) bool {
+	if locationLess(loc, locRange.Begin) {
+		return false
+	}
+	return locationLess(loc, locRange.End)
+}
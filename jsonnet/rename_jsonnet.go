@@ -0,0 +1,40 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func RenameJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "renameJsonnet",
+		Params: ast.Identifiers{"code", "line", "column", "newName"},
+		Func: func(input []any) (any, error) {
+			if len(input) != 4 {
+				return nil, fmt.Errorf("code, line, column and newName must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			line, ok := input[1].(float64)
+			if !ok {
+				return nil, fmt.Errorf("line must be a number")
+			}
+			column, ok := input[2].(float64)
+			if !ok {
+				return nil, fmt.Errorf("column must be a number")
+			}
+			newName, ok := input[3].(string)
+			if !ok {
+				return nil, fmt.Errorf("newName must be a string")
+			}
+			out, err := Rename(DefaultFilename, code, ast.Location{Line: int(line), Column: int(column)}, newName)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		line     int
+		column   int
+		newName  string
+		expected string
+	}{
+		{
+			name:     "local binding",
+			code:     "local a = 1;\n// uses a\na + a",
+			line:     1,
+			column:   7,
+			newName:  "b",
+			expected: "local b = 1;\n// uses a\nb + b\n",
+		},
+		{
+			name:     "from reference",
+			code:     "local a = 1; a + a",
+			line:     1,
+			column:   18,
+			newName:  "count",
+			expected: "local count = 1; count + count\n",
+		},
+		{
+			name:     "shadowed",
+			code:     "local a = 1; [a, local a = 2; a]",
+			line:     1,
+			column:   7,
+			newName:  "b",
+			expected: "local b = 1; [b, local a = 2; a]\n",
+		},
+		{
+			name:     "inner shadowing binding",
+			code:     "local a = 1; [a, local a = 2; a]",
+			line:     1,
+			column:   24,
+			newName:  "b",
+			expected: "local a = 1; [a, local b = 2; b]\n",
+		},
+		{
+			name:     "recursive function",
+			code:     "local f(n) = if n == 0 then 0 else f(n - 1); f(3)",
+			line:     1,
+			column:   7,
+			newName:  "g",
+			expected: "local g(n) = if n == 0 then 0 else g(n - 1); g(3)\n",
+		},
+		{
+			name:     "parameter",
+			code:     "function(x, y=x) x + y",
+			line:     1,
+			column:   10,
+			newName:  "z",
+			expected: "function(z, y=z) z + y\n",
+		},
+		{
+			name:     "for spec",
+			code:     "[x + y for x in [1, 2] for y in [x] if x > 1]",
+			line:     1,
+			column:   12,
+			newName:  "i",
+			expected: "[i + y for i in [1, 2] for y in [i] if i > 1]\n",
+		},
+		{
+			name:     "named arguments",
+			code:     "local f(x) = x; local g = function(x) x; [f(x=1), (function(x) x)(x=2), g(x=3)]",
+			line:     1,
+			column:   9,
+			newName:  "y",
+			expected: "local f(y) = y; local g = function(x) x; [f(y=1), (function(x) x)(x=2), g(x=3)]\n",
+		},
+		{
+			name:     "named arguments of anonymous function",
+			code:     "(function(x) x)(x=2)",
+			line:     1,
+			column:   11,
+			newName:  "y",
+			expected: "(function(y) y)(y=2)\n",
+		},
+		{
+			name:     "for spec with comments",
+			code:     "[x for /* for y in z */ x # for y\n in [1]]",
+			line:     1,
+			column:   25,
+			newName:  "i",
+			expected: "[i for /* for y in z */ i  // for y\n in [1]]\n",
+		},
+		{
+			name:     "for spec after strings and comments",
+			code:     "local s = 'for y in z';  /* for y in z */\n[x for x in [s, |||\n  for y in z\n|||]]",
+			line:     2,
+			column:   8,
+			newName:  "i",
+			expected: "local s = 'for y in z'; /* for y in z */\n[i for i in [s, |||\n  for y in z\n|||]]\n",
+		},
+		{
+			name:     "object comprehension",
+			code:     "{ [k]: k for k in ['a'] }",
+			line:     1,
+			column:   14,
+			newName:  "key",
+			expected: "{ [key]: key for key in ['a'] }\n",
+		},
+		{
+			name:     "object local",
+			code:     "{ local a = 1, b: a, [a]: 2 }",
+			line:     1,
+			column:   9,
+			newName:  "c",
+			expected: "{ local c = 1, b: c, [a]: 2 }\n",
+		},
+		{
+			name:     "same name",
+			code:     "local a = 1; a",
+			line:     1,
+			column:   7,
+			newName:  "a",
+			expected: "local a = 1; a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RenameJsonnet().Func([]any{tt.code, float64(tt.line), float64(tt.column), tt.newName})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRenameJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code, line, column and newName must be provided",
+		},
+		{
+			name:     "non-string code",
+			input:    []any{123, float64(1), float64(1), "a"},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-number line",
+			input:    []any{"null", "1", float64(1), "a"},
+			expected: "line must be a number",
+		},
+		{
+			name:     "non-number column",
+			input:    []any{"null", float64(1), "1", "a"},
+			expected: "column must be a number",
+		},
+		{
+			name:     "non-string name",
+			input:    []any{"null", float64(1), float64(1), 1},
+			expected: "newName must be a string",
+		},
+		{
+			name:     "no binding",
+			input:    []any{"local a = 1; a", float64(1), float64(1), "b"},
+			expected: "no binding at 1:1",
+		},
+		{
+			name:     "free variable",
+			input:    []any{"std.length([])", float64(1), float64(1), "b"},
+			expected: "std at 1:1 is not bound in this file",
+		},
+		{
+			name:     "invalid identifier",
+			input:    []any{"local a = 1; a", float64(1), float64(7), "local"},
+			expected: "invalid identifier: local",
+		},
+		{
+			name:     "duplicate binding",
+			input:    []any{"local a = 1, b = 2; a + b", float64(1), float64(7), "b"},
+			expected: "cannot rename a to b: b is already declared at 1:14",
+		},
+		{
+			name:     "reference captured",
+			input:    []any{"local a = 1; local b = 2; a + b", float64(1), float64(7), "b"},
+			expected: "cannot rename a to b: the reference at 1:27 would be captured by b declared at 1:20",
+		},
+		{
+			name:     "captures reference",
+			input:    []any{"local b = 1; local a = 2; a + b", float64(1), float64(20), "b"},
+			expected: "cannot rename a to b: it would capture the reference to b at 1:31",
+		},
+		{
+			name:     "captures std",
+			input:    []any{"local a = 2; std.length([a])", float64(1), float64(7), "std"},
+			expected: "cannot rename a to std: it would capture the reference to std at 1:14",
+		},
+		{
+			name:     "named argument to unknown function",
+			input:    []any{"local f(x) = x; local o = { g(x): x }; o.g(x=1) + f(x=2)", float64(1), float64(9), "y"},
+			expected: "cannot rename x to y: the call at 1:40 might pass it as a named argument",
+		},
+		{
+			name:     "named argument already passed",
+			input:    []any{"local f(x) = x; f(x=1, y=2)", float64(1), float64(9), "y"},
+			expected: "cannot rename x to y: the call at 1:17 already passes y",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RenameJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package jsonnet
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+type BindingKind string
+
+const (
+	BindingLocal       BindingKind = "local"
+	BindingObjectLocal BindingKind = "objectLocal"
+	BindingParameter   BindingKind = "parameter"
+	BindingForSpec     BindingKind = "forSpec"
+)
+
+type Binding struct {
+	Name       ast.Identifier
+	Kind       BindingKind
+	Loc        ast.Location
+	Body       ast.Node
+	References []*ast.Var
+	Shadows    *Binding
+	scope      *scope
+	rename     func(ast.Identifier)
+	// fun is the function a local is bound to and owner the function that
+	// declares a parameter.
+	fun   *ast.Function
+	owner *ast.Function
+}
+
+func (b *Binding) LocRange() ast.LocationRange {
+	end := b.Loc
+	end.Column += utf8.RuneCountInString(string(b.Name))
+	return ast.LocationRange{Begin: b.Loc, End: end}
+}
+
+type scope struct {
+	parent   *scope
+	bindings map[ast.Identifier]*Binding
+}
+
+func (s *scope) lookup(name ast.Identifier) *Binding {
+	for sc := s; sc != nil; sc = sc.parent {
+		if b, ok := sc.bindings[name]; ok {
+			return b
+		}
+	}
+	return nil
+}
+
+type Scopes struct {
+	Bindings  []*Binding
+	Vars      []*ast.Var
+	Undefined []*ast.Var
+	applies   []*ast.Apply
+	resolved  map[*ast.Var]*Binding
+	varScopes map[*ast.Var]*scope
+}
+
+func (s *Scopes) Resolve(v *ast.Var) *Binding {
+	return s.resolved[v]
+}
+
+func ResolveScopes(node ast.Node, source string) *Scopes {
+	r := resolver{
+		scopes: &Scopes{
+			resolved:  make(map[*ast.Var]*Binding),
+			varScopes: make(map[*ast.Var]*scope),
+		},
+		lines: sourceLines(source),
+	}
+	r.walk(node, nil)
+	sort.SliceStable(r.scopes.Bindings, func(i, j int) bool {
+		return locationLess(r.scopes.Bindings[i].Loc, r.scopes.Bindings[j].Loc)
+	})
+	return r.scopes
+}
+
+type resolver struct {
+	scopes *Scopes
+	lines  [][]rune
+}
+
+func sourceLines(source string) [][]rune {
+	var lines [][]rune
+	for _, line := range strings.Split(source, "\n") {
+		lines = append(lines, []rune(line))
+	}
+	return lines
+}
+
+func (r *resolver) declare(s *scope, b *Binding) {
+	b.scope = s
+	b.Shadows = s.parent.lookup(b.Name)
+	s.bindings[b.Name] = b
+	r.scopes.Bindings = append(r.scopes.Bindings, b)
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, bindings: make(map[ast.Identifier]*Binding)}
+}
+
+func (r *resolver) walk(node ast.Node, s *scope) {
+	switch n := node.(type) {
+	case nil:
+	case *ast.Apply:
+		r.scopes.applies = append(r.scopes.applies, n)
+		r.walk(n.Target, s)
+		for _, arg := range n.Arguments.Positional {
+			r.walk(arg.Expr, s)
+		}
+		for _, arg := range n.Arguments.Named {
+			r.walk(arg.Arg, s)
+		}
+	case *ast.ApplyBrace:
+		r.walk(n.Left, s)
+		r.walk(n.Right, s)
+	case *ast.Array:
+		for _, elem := range n.Elements {
+			r.walk(elem.Expr, s)
+		}
+	case *ast.ArrayComp:
+		inner := r.walkForSpec(&n.Spec, s, endOf(n.Body))
+		r.walk(n.Body, inner)
+	case *ast.Assert:
+		r.walk(n.Cond, s)
+		r.walk(n.Message, s)
+		r.walk(n.Rest, s)
+	case *ast.Binary:
+		r.walk(n.Left, s)
+		r.walk(n.Right, s)
+	case *ast.Conditional:
+		r.walk(n.Cond, s)
+		r.walk(n.BranchTrue, s)
+		r.walk(n.BranchFalse, s)
+	case *ast.Error:
+		r.walk(n.Expr, s)
+	case *ast.Function:
+		r.walkFunction(n, s)
+	case *ast.Index:
+		r.walk(n.Target, s)
+		r.walk(n.Index, s)
+	case *ast.Slice:
+		r.walk(n.Target, s)
+		r.walk(n.BeginIndex, s)
+		r.walk(n.EndIndex, s)
+		r.walk(n.Step, s)
+	case *ast.Local:
+		inner := newScope(s)
+		for i := range n.Binds {
+			bind := &n.Binds[i]
+			fun := bind.Fun
+			if fun == nil {
+				fun, _ = bind.Body.(*ast.Function)
+			}
+			r.declare(inner, &Binding{
+				Name:   bind.Variable,
+				Kind:   BindingLocal,
+				Loc:    bind.LocRange.Begin,
+				Body:   bind.Body,
+				rename: func(name ast.Identifier) { bind.Variable = name },
+				fun:    fun,
+			})
+		}
+		for i := range n.Binds {
+			bind := &n.Binds[i]
+			if bind.Fun != nil {
+				r.walkFunction(bind.Fun, inner)
+			} else {
+				r.walk(bind.Body, inner)
+			}
+		}
+		r.walk(n.Body, inner)
+	case *ast.Object:
+		r.walkObject(n.Fields, s, s)
+	case *ast.ObjectComp:
+		var end ast.Location
+		if len(n.Fields) > 0 {
+			end = n.Fields[len(n.Fields)-1].LocRange.End
+		}
+		inner := r.walkForSpec(&n.Spec, s, end)
+		r.walkObject(n.Fields, inner, inner)
+	case *ast.Parens:
+		r.walk(n.Inner, s)
+	case *ast.SuperIndex:
+		r.walk(n.Index, s)
+	case *ast.InSuper:
+		r.walk(n.Index, s)
+	case *ast.Unary:
+		r.walk(n.Expr, s)
+	case *ast.Var:
+		r.scopes.Vars = append(r.scopes.Vars, n)
+		r.scopes.varScopes[n] = s
+		b := s.lookup(n.Id)
+		if b == nil {
+			if n.Id != "std" {
+				r.scopes.Undefined = append(r.scopes.Undefined, n)
+			}
+			return
+		}
+		r.scopes.resolved[n] = b
+		b.References = append(b.References, n)
+	}
+}
+
+func (r *resolver) walkFunction(fun *ast.Function, s *scope) {
+	inner := newScope(s)
+	for i := range fun.Parameters {
+		param := &fun.Parameters[i]
+		r.declare(inner, &Binding{
+			Name:   param.Name,
+			Kind:   BindingParameter,
+			Loc:    param.LocRange.Begin,
+			rename: func(name ast.Identifier) { param.Name = name },
+			owner:  fun,
+		})
+	}
+	for _, param := range fun.Parameters {
+		r.walk(param.DefaultArg, inner)
+	}
+	r.walk(fun.Body, inner)
+}
+
+func (r *resolver) walkObject(fields ast.ObjectFields, outer *scope, s *scope) {
+	inner := newScope(s)
+	for i := range fields {
+		field := &fields[i]
+		if field.Kind != ast.ObjectLocal {
+			continue
+		}
+		fun := field.Method
+		if fun == nil {
+			fun, _ = field.Expr2.(*ast.Function)
+		}
+		r.declare(inner, &Binding{
+			Name:   *field.Id,
+			Kind:   BindingObjectLocal,
+			Loc:    field.LocRange.Begin,
+			Body:   field.Expr2,
+			rename: func(name ast.Identifier) { *field.Id = name },
+			fun:    fun,
+		})
+	}
+	for i := range fields {
+		field := &fields[i]
+		r.walk(field.Expr1, outer)
+		if field.Method != nil {
+			r.walkFunction(field.Method, inner)
+		} else {
+			r.walk(field.Expr2, inner)
+		}
+		r.walk(field.Expr3, inner)
+	}
+}
+
+func (r *resolver) walkForSpec(spec *ast.ForSpec, s *scope, end ast.Location) *scope {
+	var specs []*ast.ForSpec
+	for sp := spec; sp != nil; sp = sp.Outer {
+		specs = append([]*ast.ForSpec{sp}, specs...)
+	}
+	for _, sp := range specs {
+		loc := forVarLocation(r.lines, end)
+		r.walk(sp.Expr, s)
+		end = endOf(sp.Expr)
+		s = newScope(s)
+		r.declare(s, &Binding{
+			Name:   sp.VarName,
+			Kind:   BindingForSpec,
+			Loc:    loc,
+			rename: func(name ast.Identifier) { sp.VarName = name },
+		})
+		for _, cond := range sp.Conditions {
+			r.walk(cond.Expr, s)
+			end = endOf(cond.Expr)
+		}
+	}
+	return s
+}
+
+func endOf(node ast.Node) ast.Location {
+	if node == nil || node.Loc() == nil {
+		return ast.Location{}
+	}
+	return node.Loc().End
+}
+
+func locationLess(a, b ast.Location) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// forVarLocation finds the loop variable of a for spec, which the AST does not
+// record. The parser's locations bound the search: between the end of the
+// expression preceding the spec and the variable, there can only be
+// whitespace, commas, comments and the for keyword.
+func forVarLocation(lines [][]rune, from ast.Location) ast.Location {
+	if from.Line < 1 {
+		return ast.Location{}
+	}
+	c := sourceCursor{lines: lines, line: from.Line - 1, column: from.Column - 1}
+	c.skipFodder()
+	if !c.consume("for") {
+		return ast.Location{}
+	}
+	c.skipFodder()
+	return ast.Location{Line: c.line + 1, Column: c.column + 1}
+}
+
+type sourceCursor struct {
+	lines  [][]rune
+	line   int
+	column int
+}
+
+func (c *sourceCursor) rest() string {
+	if c.line >= len(c.lines) || c.column > len(c.lines[c.line]) {
+		return ""
+	}
+	return string(c.lines[c.line][c.column:])
+}
+
+func (c *sourceCursor) consume(s string) bool {
+	if !strings.HasPrefix(c.rest(), s) {
+		return false
+	}
+	c.column += utf8.RuneCountInString(s)
+	return true
+}
+
+func (c *sourceCursor) nextLine() {
+	c.line++
+	c.column = 0
+}
+
+func (c *sourceCursor) skipFodder() {
+	for c.line < len(c.lines) {
+		rest := c.rest()
+		switch {
+		case rest == "" || strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "//"):
+			c.nextLine()
+		case strings.HasPrefix(rest, "/*"):
+			c.column += 2
+			for c.line < len(c.lines) && !c.consume("*/") {
+				if c.rest() == "" {
+					c.nextLine()
+				} else {
+					c.column++
+				}
+			}
+		case strings.TrimLeft(rest[:1], " \t\r,") == "":
+			c.column++
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package jsonnet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func Template(filename string, code string, holes map[string]any) (any, error) {
+	occurrences := findHoles(code)
+	prefix, err := holePrefix(code, occurrences)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	var unknown []string
+	for _, occurrence := range occurrences {
+		if _, ok := holes[occurrence.name]; !ok && !used[occurrence.name] {
+			unknown = append(unknown, "$$"+occurrence.name)
+		}
+		used[occurrence.name] = true
+	}
+	var unused []string
+	for name := range holes {
+		if !used[name] {
+			unused = append(unused, "$$"+name)
+		}
+	}
+	sort.Strings(unknown)
+	sort.Strings(unused)
+	var problems []string
+	if len(unknown) > 0 {
+		problems = append(problems, "unknown holes: "+strings.Join(unknown, ", "))
+	}
+	if len(unused) > 0 {
+		problems = append(problems, "unused holes: "+strings.Join(unused, ", "))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+
+	t := templater{prefix: prefix, holes: make(map[string]any, len(holes))}
+	for name, value := range holes {
+		if isNode(value) {
+			if kind, _ := value.(map[string]any)["__kind__"].(string); kind == "" {
+				return nil, fmt.Errorf("hole $$%s: invalid node", name)
+			}
+			t.holes[name] = value
+			continue
+		}
+		node, err := ValueToAst(value, DefaultValueOptions())
+		if err != nil {
+			return nil, fmt.Errorf("hole $$%s: %w", name, err)
+		}
+		t.holes[name] = node
+	}
+	var b strings.Builder
+	last := 0
+	for _, occurrence := range occurrences {
+		b.WriteString(code[last:occurrence.offset])
+		b.WriteString(prefix + occurrence.name)
+		last = occurrence.offset + len(occurrence.name) + 2
+	}
+	b.WriteString(code[last:])
+	node, err := Parse(filename, b.String(), ParseOptions{Compact: true})
+	if err != nil {
+		return nil, t.restoreHoles(err)
+	}
+	return t.splice(node, "")
+}
+
+type holeOccurrence struct {
+	offset int
+	name   string
+}
+
+// findHoles lists the $$name placeholders outside of strings and comments.
+func findHoles(code string) []holeOccurrence {
+	var res []holeOccurrence
+	i := 0
+	for i < len(code) {
+		rest := code[i:]
+		switch {
+		case strings.HasPrefix(rest, "$$"):
+			n := 2
+			for n < len(rest) && isIdentifierByte(rest[n], n > 2) {
+				n++
+			}
+			if n > 2 {
+				res = append(res, holeOccurrence{offset: i, name: rest[2:n]})
+			}
+			i += n
+		case rest[0] == '#' || strings.HasPrefix(rest, "//"):
+			i += lineLength(rest)
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return res
+			}
+			i += end + 4
+		case rest[0] == '\'' || rest[0] == '"':
+			i += quotedLength(rest, rest[0])
+		case strings.HasPrefix(rest, "@'") || strings.HasPrefix(rest, "@\""):
+			i += 1 + verbatimLength(rest[1:], rest[1])
+		case strings.HasPrefix(rest, "|||"):
+			i += textBlockLength(rest)
+		default:
+			i++
+		}
+	}
+	return res
+}
+
+func isIdentifierByte(c byte, digits bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || digits && c >= '0' && c <= '9'
+}
+
+func lineLength(s string) int {
+	end := strings.IndexByte(s, '\n')
+	if end < 0 {
+		return len(s)
+	}
+	return end + 1
+}
+
+func quotedLength(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func verbatimLength(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
+// textBlockLength finds the end of a text block, which is the first line
+// indented less than its first line.
+func textBlockLength(s string) int {
+	i := lineLength(s)
+	indent := ""
+	for i < len(s) {
+		line := s[i : i+lineLength(s[i:])]
+		if strings.TrimSpace(line) == "" {
+			i += len(line)
+			continue
+		}
+		if indent == "" {
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+		if indent == "" || !strings.HasPrefix(line, indent) {
+			end := strings.Index(line, "|||")
+			if end < 0 {
+				return len(s)
+			}
+			return i + end + 3
+		}
+		i += len(line)
+	}
+	return len(s)
+}
+
+// holePrefix picks a prefix that turns every hole into an identifier of the
+// same length that does not occur in the code yet.
+func holePrefix(code string, occurrences []holeOccurrence) (string, error) {
+	const chars = "_0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	for _, c := range chars {
+		prefix := "_" + string(c)
+		clash := false
+		for _, occurrence := range occurrences {
+			if strings.Contains(code, prefix+occurrence.name) {
+				clash = true
+				break
+			}
+		}
+		if !clash {
+			return prefix, nil
+		}
+	}
+	return "", fmt.Errorf("holes clash with identifiers in the code")
+}
+
+type templater struct {
+	prefix string
+	holes  map[string]any
+}
+
+// operands are the positions in which a spliced expression that is not
+// atomic needs parentheses.
+var operands = map[string]bool{
+	"Apply.target":    true,
+	"ApplyBrace.left": true,
+	"Binary.left":     true,
+	"Binary.right":    true,
+	"Index.target":    true,
+	"InSuper.index":   true,
+	"Slice.target":    true,
+	"Unary.expr":      true,
+}
+
+var atomicKinds = map[string]bool{
+	"Apply":          true,
+	"ApplyBrace":     true,
+	"Array":          true,
+	"ArrayComp":      true,
+	"Dollar":         true,
+	"Index":          true,
+	"LiteralBoolean": true,
+	"LiteralNull":    true,
+	"LiteralNumber":  true,
+	"LiteralString":  true,
+	"Object":         true,
+	"ObjectComp":     true,
+	"Parens":         true,
+	"Self":           true,
+	"Slice":          true,
+	"SuperIndex":     true,
+	"Var":            true,
+}
+
+func (t templater) holeName(s string) (string, bool) {
+	if !strings.HasPrefix(s, t.prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(s, t.prefix)
+	_, ok := t.holes[name]
+	return name, ok
+}
+
+// restoreHoles replaces the identifiers the holes were parsed as with their
+// $$name in the message of err.
+func (t templater) restoreHoles(err error) error {
+	names := make([]string, 0, len(t.holes))
+	for name := range t.holes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	var pairs []string
+	for _, name := range names {
+		pairs = append(pairs, t.prefix+name, "$$"+name)
+	}
+	return errors.New(strings.NewReplacer(pairs...).Replace(err.Error()))
+}
+
+func (t templater) splice(node any, position string) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		kind, _ := v["__kind__"].(string)
+		if id, ok := v["id"].(string); ok && kind == "Var" {
+			if name, ok := t.holeName(id); ok {
+				spliced := t.holes[name]
+				m, _ := spliced.(map[string]any)
+				splicedKind, ok := m["__kind__"].(string)
+				if !ok {
+					return nil, fmt.Errorf("hole $$%s: invalid node", name)
+				}
+				if operands[position] && !atomicKinds[splicedKind] {
+					spliced = map[string]any{"__kind__": "Parens", "inner": spliced}
+				}
+				fodder, _ := v["fodder"].([]any)
+				return giveLeadingFodder(spliced, fodder), nil
+			}
+		}
+		res := make(map[string]any, len(v))
+		for key, val := range v {
+			if s, ok := val.(string); ok && !ignoredKey(key) {
+				if name, ok := t.holeName(s); ok {
+					return nil, fmt.Errorf("hole $$%s must be in an expression position", name)
+				}
+			}
+			child, err := t.splice(val, kind+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = child
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(v))
+		for i, elem := range v {
+			child, err := t.splice(elem, "")
+			if err != nil {
+				return nil, err
+			}
+			res[i] = child
+		}
+		return res, nil
+	default:
+		return node, nil
+	}
+}
@@ -0,0 +1,39 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func TemplateJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "templateJsonnet",
+		Params: ast.Identifiers{"code", "holes", "filename"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 2 || len(input) > 3 {
+				return nil, fmt.Errorf("code and holes must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			holes, ok := input[1].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("holes must be an object")
+			}
+			filename := DefaultFilename
+			if len(input) == 3 && input[2] != nil {
+				filename, ok = input[2].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			out, err := Template(filename, code, holes)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
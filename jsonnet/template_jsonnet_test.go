@@ -0,0 +1,201 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateJsonnet(t *testing.T) {
+	number := func(s string) map[string]any {
+		return map[string]any{"__kind__": "LiteralNumber", "originalString": s}
+	}
+	variable := func(id string) map[string]any {
+		return map[string]any{"__kind__": "Var", "id": id}
+	}
+	tests := []struct {
+		name     string
+		code     string
+		holes    map[string]any
+		expected string
+	}{
+		{
+			name:     "ast",
+			code:     "local x = $$v; x + 1",
+			holes:    map[string]any{"v": number("2")},
+			expected: "local x = 2; x + 1",
+		},
+		{
+			name:     "plain value",
+			code:     "{ a: $$v, b: $$w }",
+			holes:    map[string]any{"v": map[string]any{"c": []any{float64(1), "d"}}, "w": nil},
+			expected: "{ a: { c: [1, 'd'] }, b: null }",
+		},
+		{
+			name:     "escaped value",
+			code:     "{ a: $$v }",
+			holes:    map[string]any{"v": map[string]any{"back\\slash": "a\\b\n\"c\""}},
+			expected: `{ a: { 'back\\slash': 'a\\b\n"c"' } }`,
+		},
+		{
+			name:     "repeated hole",
+			code:     "[$$v, $$v]",
+			holes:    map[string]any{"v": true},
+			expected: "[true, true]",
+		},
+		{
+			name:     "index",
+			code:     "a[$$k]",
+			holes:    map[string]any{"k": "b-c"},
+			expected: "a['b-c']",
+		},
+		{
+			name:     "operand",
+			code:     "$$v * 2",
+			holes:    map[string]any{"v": map[string]any{"__kind__": "Binary", "op": "+", "left": variable("a"), "right": variable("b")}},
+			expected: "(a + b) * 2",
+		},
+		{
+			name:     "negative operand",
+			code:     "-$$v",
+			holes:    map[string]any{"v": float64(-1)},
+			expected: "-(-1)",
+		},
+		{
+			name:     "atomic operand",
+			code:     "$$v.b",
+			holes:    map[string]any{"v": variable("a")},
+			expected: "a.b",
+		},
+		{
+			name:     "strings and comments",
+			code:     "// $$v\n[$$v, '$$v', \"$$v\", @'$$v', |||\n  $$v\n|||, /* $$v */ 1]",
+			holes:    map[string]any{"v": number("1")},
+			expected: "// $$v\n[1, '$$v', '$$v', @'$$v', |||\n  $$v\n|||, /* $$v */ 1]",
+		},
+		{
+			name:     "leading comment",
+			code:     "[\n  // first\n  $$v,\n]",
+			holes:    map[string]any{"v": number("1")},
+			expected: "[\n  // first\n  1,\n]",
+		},
+		{
+			name:     "identifier in code",
+			code:     "local __v = 1; __v + $$v",
+			holes:    map[string]any{"v": number("2")},
+			expected: "local __v = 1; __v + 2",
+		},
+		{
+			name:     "no holes",
+			code:     "1",
+			holes:    map[string]any{},
+			expected: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TemplateJsonnet().Func([]any{tt.code, tt.holes})
+			require.NoError(t, err)
+
+			code, err := Manifest(result, formatter.DefaultOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected+"\n", code)
+		})
+	}
+}
+
+func TestTemplateJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code and holes must be provided",
+		},
+		{
+			name:     "code not a string",
+			input:    []any{1, map[string]any{}},
+			expected: "code must be a string",
+		},
+		{
+			name:     "holes not an object",
+			input:    []any{"1", []any{}},
+			expected: "holes must be an object",
+		},
+		{
+			name:     "filename not a string",
+			input:    []any{"1", map[string]any{}, 1},
+			expected: "filename must be a string",
+		},
+		{
+			name:     "unknown hole",
+			input:    []any{"$$a + $$b", map[string]any{"a": 1}},
+			expected: "unknown holes: $$b",
+		},
+		{
+			name:     "unused hole",
+			input:    []any{"$$a", map[string]any{"a": 1, "c": 2, "b": 3}},
+			expected: "unused holes: $$b, $$c",
+		},
+		{
+			name:     "unknown and unused holes",
+			input:    []any{"$$a", map[string]any{"b": 1}},
+			expected: "unknown holes: $$a; unused holes: $$b",
+		},
+		{
+			name:     "field name",
+			input:    []any{"{ $$a: 1 }", map[string]any{"a": 1}},
+			expected: "hole $$a must be in an expression position",
+		},
+		{
+			name:     "local name",
+			input:    []any{"local $$a = 1; 2", map[string]any{"a": 1}},
+			expected: "hole $$a must be in an expression position",
+		},
+		{
+			name:     "member",
+			input:    []any{"b.$$a", map[string]any{"a": 1}},
+			expected: "hole $$a must be in an expression position",
+		},
+		{
+			name:     "parameter",
+			input:    []any{"function($$a) 1", map[string]any{"a": 1}},
+			expected: "hole $$a must be in an expression position",
+		},
+		{
+			name:     "unsupported value",
+			input:    []any{"$$a", map[string]any{"a": struct{}{}}},
+			expected: "hole $$a: unsupported value",
+		},
+		{
+			name:     "syntax error",
+			input:    []any{"[$$a", map[string]any{"a": 1}},
+			expected: "main.jsonnet:1:",
+		},
+		{
+			name:     "invalid node",
+			input:    []any{"$$v * 2", map[string]any{"v": map[string]any{"__kind__": nil}}},
+			expected: "hole $$v: invalid node",
+		},
+		{
+			name:     "hole name in syntax error",
+			input:    []any{"$$a $$b", map[string]any{"a": 1, "b": 2}},
+			expected: `"$$b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TemplateJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package jsonnet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-jsonnet/formatter"
+)
+
+type TransformRule struct {
+	Pattern     map[string]any
+	Replacement any
+}
+
+func NewTransformRules(val any) ([]TransformRule, error) {
+	rawRules, ok := val.([]any)
+	if !ok {
+		return nil, fmt.Errorf("rules must be an array")
+	}
+	var rules []TransformRule
+	for i, rawRule := range rawRules {
+		rule, ok := rawRule.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("rule %d must be an object", i)
+		}
+		pattern, ok := rule["pattern"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("rule %d must have a pattern node", i)
+		}
+		replacement, ok := rule["replacement"]
+		if !ok {
+			return nil, fmt.Errorf("rule %d must have a replacement", i)
+		}
+		rules = append(rules, TransformRule{Pattern: pattern, Replacement: replacement})
+	}
+	return rules, nil
+}
+
+func Transform(filename string, code string, rules []TransformRule, options formatter.Options) (string, error) {
+	node, err := Parse(filename, code, DefaultParseOptions())
+	if err != nil {
+		return "", err
+	}
+	node, err = TransformNode(node, rules)
+	if err != nil {
+		return "", err
+	}
+	return Manifest(node, options)
+}
+
+func TransformNode(node any, rules []TransformRule) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for key, val := range v {
+			if ignoredKey(key) {
+				res[key] = val
+				continue
+			}
+			child, err := TransformNode(val, rules)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = child
+		}
+		if _, ok := res["__kind__"]; !ok {
+			return res, nil
+		}
+		leading, stripped := takeLeadingFodder(res)
+		for _, rule := range rules {
+			captures := make(map[string]any)
+			if !matchPattern(rule.Pattern, stripped, captures) {
+				continue
+			}
+			replacement, err := substitute(rule.Replacement, captures)
+			if errors.Is(err, errNotIdentifier) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return giveLeadingFodder(replacement, leading), nil
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(v))
+		for i, elem := range v {
+			child, err := TransformNode(elem, rules)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = child
+		}
+		return res, nil
+	default:
+		return node, nil
+	}
+}
+
+func matchPattern(pattern any, node any, captures map[string]any) bool {
+	if name, ok := captureName(pattern); ok {
+		if captured, ok := captures[name]; ok {
+			return structurallyEqual(captured, node)
+		}
+		captures[name] = node
+		return true
+	}
+	switch p := pattern.(type) {
+	case map[string]any:
+		n, ok := node.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, val := range p {
+			if ignoredKey(key) {
+				continue
+			}
+			if !matchPattern(val, n[key], captures) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		n, ok := node.([]any)
+		if !ok || len(n) != len(p) {
+			return false
+		}
+		for i := range p {
+			if !matchPattern(p[i], n[i], captures) {
+				return false
+			}
+		}
+		return true
+	default:
+		return pattern == node
+	}
+}
+
+func structurallyEqual(a any, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, val := range x {
+			if !ignoredKey(key) && !structurallyEqual(val, y[key]) {
+				return false
+			}
+		}
+		for key, val := range y {
+			if _, ok := x[key]; !ok && !ignoredKey(key) && val != nil {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !structurallyEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+var errNotIdentifier = errors.New("capture cannot be used as an identifier")
+
+var identifierKeys = map[string]bool{
+	"id":       true,
+	"name":     true,
+	"variable": true,
+	"varName":  true,
+}
+
+func substitute(replacement any, captures map[string]any) (any, error) {
+	switch r := replacement.(type) {
+	case map[string]any:
+		if name, ok := captureName(r); ok {
+			captured, ok := captures[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown capture: %s", name)
+			}
+			if _, ok := captured.(string); ok {
+				return nil, fmt.Errorf("capture %s is an identifier and cannot be used as an expression", name)
+			}
+			return captured, nil
+		}
+		res := make(map[string]any, len(r))
+		for key, val := range r {
+			if name, ok := captureName(val); ok && identifierKeys[key] {
+				id, err := captureIdentifier(name, captures)
+				if err != nil {
+					return nil, err
+				}
+				res[key] = id
+				continue
+			}
+			child, err := substitute(val, captures)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = child
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(r))
+		for i, elem := range r {
+			child, err := substitute(elem, captures)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = child
+		}
+		return res, nil
+	default:
+		return replacement, nil
+	}
+}
+
+func captureIdentifier(name string, captures map[string]any) (string, error) {
+	captured, ok := captures[name]
+	if !ok {
+		return "", fmt.Errorf("unknown capture: %s", name)
+	}
+	switch c := captured.(type) {
+	case string:
+		return c, nil
+	case map[string]any:
+		if value, ok := c["value"].(string); ok && c["__kind__"] == "LiteralString" && isIdentifier(value) {
+			return value, nil
+		}
+	}
+	return "", errNotIdentifier
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9' {
+			continue
+		}
+		return false
+	}
+	return !keywords[s]
+}
+
+var keywords = map[string]bool{
+	"assert": true, "else": true, "error": true, "false": true, "for": true, "function": true,
+	"if": true, "import": true, "importstr": true, "importbin": true, "in": true, "local": true,
+	"null": true, "tailstrict": true, "then": true, "self": true, "super": true, "true": true,
+}
+
+func captureName(val any) (string, bool) {
+	m, ok := val.(map[string]any)
+	if !ok || m["__kind__"] != "Capture" {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok
+}
+
+var leftmostChildren = map[string]string{
+	"Apply":      "target",
+	"ApplyBrace": "left",
+	"Binary":     "left",
+	"Index":      "target",
+	"InSuper":    "index",
+	"Slice":      "target",
+}
+
+func takeLeadingFodder(node map[string]any) ([]any, map[string]any) {
+	res := make(map[string]any, len(node))
+	for key, val := range node {
+		res[key] = val
+	}
+	if fodder, ok := node["fodder"].([]any); ok && len(fodder) > 0 {
+		res["fodder"] = []any{}
+		return fodder, res
+	}
+	kind, _ := node["__kind__"].(string)
+	child, ok := node[leftmostChildren[kind]].(map[string]any)
+	if !ok {
+		return nil, node
+	}
+	fodder, stripped := takeLeadingFodder(child)
+	res[leftmostChildren[kind]] = stripped
+	return fodder, res
+}
+
+func giveLeadingFodder(node any, fodder []any) any {
+	n, ok := node.(map[string]any)
+	if !ok || len(fodder) == 0 {
+		return node
+	}
+	res := make(map[string]any, len(n))
+	for key, val := range n {
+		res[key] = val
+	}
+	kind, _ := n["__kind__"].(string)
+	if child, ok := n[leftmostChildren[kind]].(map[string]any); ok {
+		if existing, _ := n["fodder"].([]any); len(existing) == 0 {
+			res[leftmostChildren[kind]] = giveLeadingFodder(child, fodder)
+			return res
+		}
+	}
+	existing, _ := n["fodder"].([]any)
+	res["fodder"] = append(append([]any{}, fodder...), existing...)
+	return res
+}
+
+func ignoredKey(key string) bool {
+	switch key {
+	case "locRange", "freeVars", "context", "trailingComma":
+		return true
+	}
+	return strings.HasSuffix(key, "Fodder") || strings.HasPrefix(key, "fodder")
+}
@@ -0,0 +1,47 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func TransformJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "transformJsonnet",
+		Params: ast.Identifiers{"code", "rules", "options", "filename"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 2 || len(input) > 4 {
+				return nil, fmt.Errorf("code and rules must be provided")
+			}
+			code, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("code must be a string")
+			}
+			rules, err := NewTransformRules(input[1])
+			if err != nil {
+				return nil, err
+			}
+			var rawOptions any
+			if len(input) >= 3 {
+				rawOptions = input[2]
+			}
+			options, err := NewFormatOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			filename := DefaultFilename
+			if len(input) == 4 && input[3] != nil {
+				filename, ok = input[3].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			out, err := Transform(filename, code, rules, options)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func capture(name string) map[string]any {
+	return map[string]any{"__kind__": "Capture", "name": name}
+}
+
+func extVarPattern(arg any) map[string]any {
+	return map[string]any{
+		"__kind__": "Apply",
+		"target": map[string]any{
+			"__kind__": "Index",
+			"target":   map[string]any{"__kind__": "Var", "id": "std"},
+			"id":       "extVar",
+		},
+		"arguments": map[string]any{
+			"positional": []any{map[string]any{"__kind__": "CommaSeparatedExpr", "expr": arg}},
+			"named":      []any{},
+		},
+	}
+}
+
+func TestTransformJsonnet(t *testing.T) {
+	configMember := map[string]any{
+		"pattern": extVarPattern(capture("x")),
+		"replacement": map[string]any{
+			"__kind__": "Index",
+			"target":   map[string]any{"__kind__": "Var", "id": "config"},
+			"id":       capture("x"),
+		},
+	}
+	tests := []struct {
+		name     string
+		code     string
+		rules    []any
+		expected string
+	}{
+		{
+			name:     "no rules",
+			code:     "{\n  // comment\n  a: 1,\n}",
+			rules:    []any{},
+			expected: "{\n  // comment\n  a: 1,\n}\n",
+		},
+		{
+			name: "literal pattern",
+			code: "std.extVar('x')",
+			rules: []any{map[string]any{
+				"pattern":     extVarPattern(map[string]any{"__kind__": "LiteralString", "value": "x"}),
+				"replacement": map[string]any{"__kind__": "Var", "id": "x"},
+			}},
+			expected: "x\n",
+		},
+		{
+			name:     "capture as identifier",
+			code:     "local config = import 'config.libsonnet';\n{\n  // the name\n  name: std.extVar('name'),\n\n  other: std.extVar('other') + 1,  // trailing\n}",
+			rules:    []any{configMember},
+			expected: "local config = import 'config.libsonnet';\n{\n  // the name\n  name: config.name,\n\n  other: config.other + 1,  // trailing\n}\n",
+		},
+		{
+			name:     "capture not an identifier",
+			code:     "[std.extVar('foo-bar'), std.extVar(name)]",
+			rules:    []any{configMember},
+			expected: "[std.extVar('foo-bar'), std.extVar(name)]\n",
+		},
+		{
+			name: "capture as expression",
+			code: "[a + a, a + b, f(x) + f(x)]",
+			rules: []any{map[string]any{
+				"pattern": map[string]any{"__kind__": "Binary", "op": "+", "left": capture("x"), "right": capture("x")},
+				"replacement": map[string]any{
+					"__kind__": "Binary",
+					"op":       "*",
+					"left":     map[string]any{"__kind__": "LiteralNumber", "originalString": "2"},
+					"right":    capture("x"),
+				},
+			}},
+			expected: "[2 * a, a + b, 2 * f(x)]\n",
+		},
+		{
+			name: "nested rewrite",
+			code: "std.extVar(std.extVar('a'))",
+			rules: []any{map[string]any{
+				"pattern": extVarPattern(capture("x")),
+				"replacement": map[string]any{
+					"__kind__": "Array",
+					"elements": []any{map[string]any{"__kind__": "CommaSeparatedExpr", "expr": capture("x")}},
+				},
+			}},
+			expected: "[['a']]\n",
+		},
+		{
+			name:     "leading comment",
+			code:     "// comment\nstd.extVar('a')",
+			rules:    []any{configMember},
+			expected: "// comment\nconfig.a\n",
+		},
+		{
+			name: "leading comment on capture",
+			code: "// comment\na + b",
+			rules: []any{map[string]any{
+				"pattern":     map[string]any{"__kind__": "Binary", "op": "+", "left": capture("x"), "right": capture("y")},
+				"replacement": map[string]any{"__kind__": "Binary", "op": "+", "left": capture("y"), "right": capture("x")},
+			}},
+			expected: "// comment\nb + a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TransformJsonnet().Func([]any{tt.code, tt.rules})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTransformJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "code and rules must be provided",
+		},
+		{
+			name:     "non-string code",
+			input:    []any{123, []any{}},
+			expected: "code must be a string",
+		},
+		{
+			name:     "non-array rules",
+			input:    []any{"null", map[string]any{}},
+			expected: "rules must be an array",
+		},
+		{
+			name:     "rule without pattern",
+			input:    []any{"null", []any{map[string]any{"replacement": nil}}},
+			expected: "rule 0 must have a pattern node",
+		},
+		{
+			name:     "rule without replacement",
+			input:    []any{"null", []any{map[string]any{"pattern": map[string]any{}}}},
+			expected: "rule 0 must have a replacement",
+		},
+		{
+			name: "unknown capture",
+			input: []any{"null", []any{map[string]any{
+				"pattern":     map[string]any{"__kind__": "LiteralNull"},
+				"replacement": capture("x"),
+			}}},
+			expected: "unknown capture: x",
+		},
+		{
+			name:     "invalid options",
+			input:    []any{"null", []any{}, map[string]any{"foo": 1}},
+			expected: "invalid options",
+		},
+		{
+			name:     "parse error",
+			input:    []any{"{", []any{}},
+			expected: "main.jsonnet:1:2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TransformJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func TryParseJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "tryParseJsonnet",
+		Params: ast.Identifiers{"jsonnet", "options", "filename"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 1 || len(input) > 3 {
+				return nil, fmt.Errorf("jsonnet must be provided")
+			}
+			md, ok := input[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("jsonnet must be a string")
+			}
+			var rawOptions any
+			if len(input) >= 2 {
+				rawOptions = input[1]
+			}
+			options, err := NewParseOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			filename := DefaultFilename
+			if len(input) == 3 && input[2] != nil {
+				filename, ok = input[2].(string)
+				if !ok {
+					return nil, fmt.Errorf("filename must be a string")
+				}
+			}
+			out, err := TryParse(filename, md, options)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
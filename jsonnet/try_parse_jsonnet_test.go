@@ -0,0 +1,122 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryParseJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected map[string]any
+	}{
+		{
+			name:  "unexpected token",
+			input: []any{"{a: }"},
+			expected: map[string]any{
+				"ok": false,
+				"errors": []any{map[string]any{
+					"message": "Unexpected: \"}\" while parsing terminal",
+					"file":    "main.jsonnet",
+					"begin":   map[string]any{"line": float64(1), "column": float64(5)},
+					"end":     map[string]any{"line": float64(1), "column": float64(6)},
+				}},
+			},
+		},
+		{
+			name:  "unexpected end of file",
+			input: []any{"local a = 1\n"},
+			expected: map[string]any{
+				"ok": false,
+				"errors": []any{map[string]any{
+					"message": "Expected , or ; but got end of file",
+					"file":    "main.jsonnet",
+					"begin":   map[string]any{"line": float64(2), "column": float64(1)},
+					"end":     map[string]any{"line": float64(2), "column": float64(1)},
+				}},
+			},
+		},
+		{
+			name:  "unterminated string",
+			input: []any{"[\n  'foo\n]"},
+			expected: map[string]any{
+				"ok": false,
+				"errors": []any{map[string]any{
+					"message": "Unterminated String",
+					"file":    "main.jsonnet",
+					"begin":   map[string]any{"line": float64(2), "column": float64(3)},
+					"end":     map[string]any{"line": float64(2), "column": float64(3)},
+				}},
+			},
+		},
+		{
+			name:  "filename",
+			input: []any{"{a: }", nil, "lib/config.libsonnet"},
+			expected: map[string]any{
+				"ok": false,
+				"errors": []any{map[string]any{
+					"message": "Unexpected: \"}\" while parsing terminal",
+					"file":    "lib/config.libsonnet",
+					"begin":   map[string]any{"line": float64(1), "column": float64(5)},
+					"end":     map[string]any{"line": float64(1), "column": float64(6)},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TryParseJsonnet().Func(tt.input)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTryParseJsonnetOk(t *testing.T) {
+	result, err := TryParseJsonnet().Func([]any{"null", map[string]any{"compact": true}})
+	require.NoError(t, err)
+
+	res := result.(map[string]any)
+	assert.Equal(t, true, res["ok"])
+	assert.NotContains(t, res, "errors")
+	assert.Equal(t, "LiteralNull", res["ast"].(map[string]any)["__kind__"])
+}
+
+func TestTryParseJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "jsonnet must be provided",
+		},
+		{
+			name:     "non-string argument",
+			input:    []any{123},
+			expected: "jsonnet must be a string",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{"null", map[string]any{"minify": true}},
+			expected: "unknown field \"minify\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TryParseJsonnet().Func(tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
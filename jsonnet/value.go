@@ -0,0 +1,150 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet/ast"
+)
+
+func ValueToAst(value any, options ValueOptions) (any, error) {
+	node, err := ValueToNode(value, options)
+	if err != nil {
+		return nil, err
+	}
+	return nodeToValue(node, ParseOptions{Compact: true})
+}
+
+func ValueToNode(value any, options ValueOptions) (ast.Node, error) {
+	c := valueConverter{multiline: options.Multiline, order: make(map[string]int)}
+	for i, key := range options.FieldOrder {
+		if _, ok := c.order[key]; !ok {
+			c.order[key] = i
+		}
+	}
+	return c.convert(value, "$")
+}
+
+type valueConverter struct {
+	multiline bool
+	order     map[string]int
+}
+
+func (c valueConverter) convert(value any, path string) (ast.Node, error) {
+	switch v := value.(type) {
+	case nil:
+		return &ast.LiteralNull{}, nil
+	case bool:
+		return &ast.LiteralBoolean{Value: v}, nil
+	case string:
+		return stringNode(v), nil
+	case float64:
+		return number(v)
+	case int:
+		return number(float64(v))
+	case []any:
+		return c.array(v, path)
+	case map[string]any:
+		return c.object(v, path)
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T at %s", value, path)
+	}
+}
+
+func number(v float64) (ast.Node, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return numberNode(string(b)), nil
+}
+
+func numberNode(s string) ast.Node {
+	if strings.HasPrefix(s, "-") {
+		return &ast.Unary{Op: ast.UopMinus, Expr: &ast.LiteralNumber{OriginalString: s[1:]}}
+	}
+	return &ast.LiteralNumber{OriginalString: s}
+}
+
+func (c valueConverter) array(v []any, path string) (ast.Node, error) {
+	array := &ast.Array{Elements: make([]ast.CommaSeparatedExpr, len(v))}
+	for i, elem := range v {
+		expr, err := c.convert(elem, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		if c.multiline {
+			*expr.OpenFodder() = lineEnd()
+		}
+		array.Elements[i].Expr = expr
+	}
+	if c.multiline && len(v) > 0 {
+		array.CloseFodder = lineEnd()
+		array.TrailingComma = true
+	}
+	return array, nil
+}
+
+func (c valueConverter) object(v map[string]any, path string) (ast.Node, error) {
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		oi, iok := c.order[keys[i]]
+		oj, jok := c.order[keys[j]]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok || jok:
+			return iok
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+	object := &ast.Object{Fields: make(ast.ObjectFields, len(keys))}
+	for i, key := range keys {
+		expr, err := c.convert(v[key], path+"."+key)
+		if err != nil {
+			return nil, err
+		}
+		field := objectField(key, expr)
+		if c.multiline {
+			field.Fodder1 = lineEnd()
+		}
+		object.Fields[i] = field
+	}
+	if c.multiline && len(keys) > 0 {
+		object.CloseFodder = lineEnd()
+		object.TrailingComma = true
+	}
+	return object, nil
+}
+
+func objectField(key string, expr ast.Node) ast.ObjectField {
+	field := ast.ObjectField{Hide: ast.ObjectFieldInherit, Expr2: expr}
+	if isIdentifier(key) {
+		id := ast.Identifier(key)
+		field.Kind = ast.ObjectFieldID
+		field.Id = &id
+	} else {
+		field.Kind = ast.ObjectFieldStr
+		field.Expr1 = stringNode(key)
+	}
+	return field
+}
+
+// stringNode quotes s with single quotes, or with double quotes when that
+// saves escaping the single quotes in s.
+func stringNode(s string) *ast.LiteralString {
+	if strings.Contains(s, "'") && !strings.Contains(s, `"`) {
+		return &ast.LiteralString{Value: escapeString(s, '"'), Kind: ast.StringDouble}
+	}
+	return &ast.LiteralString{Value: escapeString(s, '\''), Kind: ast.StringSingle}
+}
+
+func lineEnd() ast.Fodder {
+	return ast.Fodder{{Kind: ast.FodderLineEnd}}
+}
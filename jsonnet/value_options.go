@@ -0,0 +1,37 @@
+package jsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type ValueOptions struct {
+	FieldOrder []string `json:"fieldOrder"`
+	Multiline  bool     `json:"multiline"`
+}
+
+func DefaultValueOptions() ValueOptions {
+	return ValueOptions{}
+}
+
+func NewValueOptions(val any) (ValueOptions, error) {
+	options := DefaultValueOptions()
+	if val == nil {
+		return options, nil
+	}
+	if _, ok := val.(map[string]any); !ok {
+		return options, fmt.Errorf("options must be an object")
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return options, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&options)
+	if err != nil {
+		return options, fmt.Errorf("invalid options: %w", err)
+	}
+	return options, nil
+}
@@ -0,0 +1,32 @@
+package jsonnet
+
+import (
+	"fmt"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+)
+
+func ValueToAstJsonnet() jsonnet.NativeFunction {
+	return jsonnet.NativeFunction{
+		Name:   "valueToAst",
+		Params: ast.Identifiers{"value", "options"},
+		Func: func(input []any) (any, error) {
+			if len(input) < 1 || len(input) > 2 {
+				return nil, fmt.Errorf("value must be provided")
+			}
+			var rawOptions any
+			if len(input) == 2 {
+				rawOptions = input[1]
+			}
+			options, err := NewValueOptions(rawOptions)
+			if err != nil {
+				return nil, err
+			}
+			out, err := ValueToAst(input[0], options)
+			if err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package jsonnet
+
+import (
+	"testing"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueToAstJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		options  any
+		expected string
+	}{
+		{
+			name:     "null",
+			value:    nil,
+			expected: "null",
+		},
+		{
+			name:     "boolean",
+			value:    true,
+			expected: "true",
+		},
+		{
+			name:     "string",
+			value:    "it's",
+			expected: `"it's"`,
+		},
+		{
+			name:     "escaped string",
+			value:    "back\\slash \"quoted\" line\nnext\ttab \x01 ünï",
+			expected: `'back\\slash "quoted" line\nnext\ttab \u0001 ünï'`,
+		},
+		{
+			name:     "escaped keys",
+			value:    map[string]any{"back\\slash": "it's", "line\nnext": float64(1), "\"ünï\"": float64(2)},
+			expected: `{ '"ünï"': 2, 'back\\slash': "it's", 'line\nnext': 1 }`,
+		},
+		{
+			name:     "number",
+			value:    float64(1.5),
+			expected: "1.5",
+		},
+		{
+			name:     "negative number",
+			value:    float64(-3),
+			expected: "-3",
+		},
+		{
+			name:     "large number",
+			value:    float64(1e21),
+			expected: "1e+21",
+		},
+		{
+			name:     "array",
+			value:    []any{float64(1), "a", []any{}, map[string]any{}},
+			expected: "[1, 'a', [], {}]",
+		},
+		{
+			name:     "object keys",
+			value:    map[string]any{"b": float64(1), "a-b": float64(2), "local": float64(3), "_c1": float64(4)},
+			expected: "{ _c1: 4, 'a-b': 2, b: 1, 'local': 3 }",
+		},
+		{
+			name:     "field order",
+			value:    map[string]any{"a": float64(1), "b": float64(2), "c": float64(3), "d": float64(4)},
+			options:  map[string]any{"fieldOrder": []any{"c", "x", "a"}},
+			expected: "{ c: 3, a: 1, b: 2, d: 4 }",
+		},
+		{
+			name:     "multiline",
+			value:    map[string]any{"a": []any{float64(1), map[string]any{"b": nil}}, "c": []any{}},
+			options:  map[string]any{"multiline": true},
+			expected: "{\n  a: [\n    1,\n    {\n      b: null,\n    },\n  ],\n  c: [],\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValueToAstJsonnet().Func([]any{tt.value, tt.options})
+			require.NoError(t, err)
+
+			code, err := Manifest(result, formatter.DefaultOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected+"\n", code)
+
+			evaluated, err := Eval(DefaultFilename, code, DefaultEvaluateOptions())
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, evaluated)
+		})
+	}
+}
+
+func TestValueToAstJsonnetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "value must be provided",
+		},
+		{
+			name:     "too many arguments",
+			input:    []any{nil, nil, nil},
+			expected: "value must be provided",
+		},
+		{
+			name:     "options not an object",
+			input:    []any{nil, "multiline"},
+			expected: "options must be an object",
+		},
+		{
+			name:     "unknown option",
+			input:    []any{nil, map[string]any{"sort": true}},
+			expected: "invalid options",
+		},
+		{
+			name:     "unsupported value",
+			input:    []any{map[string]any{"a": []any{float64(1), struct{}{}}}},
+			expected: "unsupported value of type struct {} at $.a[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValueToAstJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package jsonnet
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYamlToJsonnet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: "null\n",
+		},
+		{
+			name:     "scalars",
+			input:    "[a, 'b c', 1, -2.5, 0x1f, true, ~, 2024-01-01]",
+			expected: "[\n  'a',\n  'b c',\n  1,\n  -2.5,\n  31,\n  true,\n  null,\n  '2024-01-01',\n]\n",
+		},
+		{
+			name:     "mapping",
+			input:    "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web\n  labels: {}\n",
+			expected: "{\n  apiVersion: 'v1',\n  kind: 'Pod',\n  metadata: {\n    name: 'web',\n    labels: {},\n  },\n}\n",
+		},
+		{
+			name:     "literal block",
+			input:    "script: |\n  set -e\n  echo hi\n",
+			expected: "{\n  script: |||\n    set -e\n    echo hi\n  |||,\n}\n",
+		},
+		{
+			name:     "escapes",
+			input:    "b: 'back\\slash'\n'it''s': \"tab\\there \\\"ü\\\"\"\n",
+			expected: "{\n  b: 'back\\\\slash',\n  \"it's\": 'tab\\there \"ü\"',\n}\n",
+		},
+		{
+			name:     "chomped literal block",
+			input:    "script: |-\n  set -e\n  echo hi\n",
+			expected: "{\n  script: |||-\n    set -e\n    echo hi\n  |||,\n}\n",
+		},
+		{
+			name:     "literal block with leading blank line",
+			input:    "script: |\n\n  echo hi\n  done\n",
+			expected: "{\n  script: |||\n\n    echo hi\n    done\n  |||,\n}\n",
+		},
+		{
+			name:     "literal block not fit for a text block",
+			input:    "script: |2\n    indented\n  echo \\ hi\n",
+			expected: "{\n  script: '  indented\\necho \\\\ hi\\n',\n}\n",
+		},
+		{
+			name:     "comments",
+			input:    "# pod\n\n# name\nname: web # inline\n# ports\nports: # list\n  - 80 # http\n  # tls\n  - 443\n# end\n",
+			expected: "// pod\n\n{\n  // name\n  name: 'web',  // inline\n  // ports\n  ports: [  // list\n    80,  // http\n    // tls\n    443,\n  ],\n  // end\n}\n",
+		},
+		{
+			name:     "multiple documents",
+			input:    "a: 1\n---\n# second\nb: 2\n",
+			expected: "[\n  {\n    a: 1,\n  },\n  {\n    // second\n    b: 2,\n  },\n]\n",
+		},
+		{
+			name:     "anchors and merge keys",
+			input:    "base: &base\n  a: 1\n  b: 2\nderived:\n  <<: *base\n  b: 3\n",
+			expected: "{\n  base: {\n    a: 1,\n    b: 2,\n  },\n  derived: {\n    a: 1,\n    b: 3,\n  },\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := YamlToJsonnetJsonnet().Func([]any{tt.input})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestYamlToJsonnetErrors(t *testing.T) {
+	laughs := "a: &a [lol, lol, lol, lol, lol, lol, lol, lol, lol]\n"
+	for _, name := range []string{"b", "c", "d", "e", "f", "g", "h", "i"} {
+		prev := string(rune(name[0] - 1))
+		laughs += fmt.Sprintf("%s: &%s [%s]\n", name, name, strings.TrimSuffix(strings.Repeat("*"+prev+", ", 9), ", "))
+	}
+
+	tests := []struct {
+		name     string
+		input    []any
+		expected string
+	}{
+		{
+			name:     "no arguments",
+			input:    []any{},
+			expected: "text must be provided",
+		},
+		{
+			name:     "not a string",
+			input:    []any{nil},
+			expected: "text must be a string",
+		},
+		{
+			name:     "invalid",
+			input:    []any{"a: [1"},
+			expected: "invalid YAML",
+		},
+		{
+			name:     "duplicate key",
+			input:    []any{"a: 1\na: 2\n"},
+			expected: `line 2: duplicate key "a"`,
+		},
+		{
+			name:     "infinity",
+			input:    []any{"a: .inf\n"},
+			expected: "line 1: .inf cannot be represented in Jsonnet",
+		},
+		{
+			name:     "complex key",
+			input:    []any{"? [a]\n: 1\n"},
+			expected: "line 1: mapping keys must be scalars",
+		},
+		{
+			name:     "recursive alias",
+			input:    []any{"a: &x [*x]\n"},
+			expected: "recursive alias at line 1",
+		},
+		{
+			name:     "recursive merge",
+			input:    []any{"a: &x\n  b: 1\n  <<: *x\n"},
+			expected: "recursive alias at line 3",
+		},
+		{
+			name:     "billion laughs",
+			input:    []any{laughs},
+			expected: "aliases expand to more than 100000 nodes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := YamlToJsonnetJsonnet().Func(tt.input)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"os"
+
+	"github.com/marcbran/jsonnet-plugin-jsonnet/cli"
 	"github.com/marcbran/jsonnet-plugin-jsonnet/jsonnet"
 )
 
 func main() {
-	jsonnet.Plugin().Serve()
+	if len(os.Args) < 2 {
+		jsonnet.Plugin().Serve()
+		return
+	}
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
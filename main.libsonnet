@@ -1,190 +1,151 @@
-local wrapArray(val) = if std.type(val) == 'array' then val else [val];
-
-local NodeBase = {
-  fodder(f):: self { fodder::: wrapArray(f) },
-};
+local ast = import 'ast.libsonnet';
 
 {
-  Null: NodeBase {
-    __kind__: 'LiteralNull',
-  },
-  True: NodeBase {
-    __kind__: 'LiteralBoolean',
+  Null: ast.LiteralNull,
+  True: ast.LiteralBoolean {
     value: true,
   },
-  False: NodeBase {
-    __kind__: 'LiteralBoolean',
+  False: ast.LiteralBoolean {
     value: false,
   },
-  Self: NodeBase {
-    __kind__: 'Self',
-  },
-  Dollar: NodeBase {
-    __kind__: 'Dollar',
-  },
+  Self: ast.Self,
+  Dollar: ast.Dollar,
   String(value, format=null):
     if format == null
-    then NodeBase {
-      __kind__: 'LiteralString',
+    then ast.LiteralString {
       value: value,
     }
-    else $.Percent({
-      __kind__: 'LiteralString',
+    else $.Percent(ast.LiteralString {
       value: value,
     }, format),
-  Number(value): NodeBase {
-    __kind__: 'LiteralNumber',
+  TextBlock(value): ast.LiteralString {
+    value: value,
+    kind: 'block',
+  },
+  VerbatimString(value): ast.LiteralString {
+    value: value,
+    kind: 'verbatimSingle',
+  },
+  Number(value): ast.LiteralNumber {
     originalString: value,
   },
-  Var(id): NodeBase {
-    __kind__: 'Var',
+  Var(id): ast.Var {
     id: id,
   },
 
-  Index(target, index): NodeBase {
-    __kind__: 'Index',
+  Index(target, index): ast.Index {
     target: target,
     index: index,
-    leftBracketFodder(f):: self { leftBracketFodder::: wrapArray(f) },
-    rightBracketFodder(f):: self { rightBracketFodder::: wrapArray(f) },
   },
-  Member(target, id): NodeBase {
-    __kind__: 'Index',
+  // For `e.f` go-jsonnet keeps the fodder before the '.' in leftBracketFodder
+  // and the fodder before the 'f' in rightBracketFodder.
+  Member(target, id): ast.Index {
     target: target,
     id: id,
-    dotLeftFodder(f):: self { leftBracketFodder::: wrapArray(f) },
-    dotRightFodder(f):: self { rightBracketFodder::: wrapArray(f) },
+    dotLeftFodder(f):: self.leftBracketFodder(f),
+    dotRightFodder(f):: self.rightBracketFodder(f),
   },
-  Slice(target, begin, end, step): NodeBase {
-    __kind__: 'Slice',
+  Slice(target, begin, end, step): ast.Slice {
     target: target,
     beginIndex: begin,
     endIndex: end,
     step: step,
-    leftBracketFodder(f):: self { leftBracketFodder::: wrapArray(f) },
-    endColonFodder(f):: self { endColonFodder::: wrapArray(f) },
-    stepColonFodder(f):: self { stepColonFodder::: wrapArray(f) },
-    rightBracketFodder(f):: self { rightBracketFodder::: wrapArray(f) },
   },
 
-  SuperIndex(index): NodeBase {
-    __kind__: 'SuperIndex',
+  // SuperIndex uses dotFodder and idFodder for both `super[e]` and `super.f`.
+  SuperIndex(index): ast.SuperIndex {
     index: index,
-    leftBracketFodder(f):: self { dotFodder::: wrapArray(f) },
-    rightBracketFodder(f):: self { idFodder::: wrapArray(f) },
+    leftBracketFodder(f):: self.dotFodder(f),
+    rightBracketFodder(f):: self.idFodder(f),
   },
-  SuperMember(id): NodeBase {
-    __kind__: 'SuperIndex',
+  SuperMember(id): ast.SuperIndex {
     id: id,
-    dotLeftFodder(f):: self { dotFodder::: wrapArray(f) },
-    dotRightFodder(f):: self { idFodder::: wrapArray(f) },
+    dotLeftFodder(f):: self.dotFodder(f),
+    dotRightFodder(f):: self.idFodder(f),
   },
-  InSuper(index): NodeBase {
-    __kind__: 'InSuper',
+  InSuper(index): ast.InSuper {
     index: index,
-    inFodder(f):: self { inFodder::: wrapArray(f) },
-    superFodder(f):: self { superFodder::: wrapArray(f) },
   },
 
-  Function(parameters, body): NodeBase {
-    __kind__: 'Function',
+  Function(parameters, body): ast.Function {
     parameters: parameters,
     body: body,
-    parenLeftFodder(f):: self { parenLeftFodder::: wrapArray(f) },
-    parenRightFodder(f):: self { parenRightFodder::: wrapArray(f) },
   },
-  Parameter(name, defaultArg=null): NodeBase {
-    __kind__: 'Parameter',
+  Parameter(name, defaultArg=null): ast.Parameter {
     name: name,
     defaultArg: defaultArg,
-    nameFodder(f):: self { nameFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
-    eqFodder(f):: self { eqFodder::: wrapArray(f) },
   },
 
-  Apply(target, positional=[], named=[]): NodeBase {
-    __kind__: 'Apply',
+  Apply(target, positional=[], named=[]): ast.Apply {
     target: target,
     arguments: {
       positional: [if pos.__kind__ == 'CommaSeparatedExpr' then pos else $.CommaSeparatedExpr(pos) for pos in positional],
       named: named,
     },
-    leftFodder(f):: self { fodderLeft::: wrapArray(f) },
-    rightFodder(f):: self { fodderRight::: wrapArray(f) },
-    tailStrictFodder(f):: self { tailStrictFodder::: wrapArray(f) },
+    leftFodder(f):: self.fodderLeft(f),
+    rightFodder(f):: self.fodderRight(f),
   },
-  CommaSeparatedExpr(expr): {
-    __kind__: 'CommaSeparatedExpr',
+  CommaSeparatedExpr(expr): ast.CommaSeparatedExpr {
     expr: expr,
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
   },
-  NamedArgument(name, arg): {
-    __kind__: 'NamedArgument',
+  NamedArgument(name, arg): ast.NamedArgument {
     name: name,
     arg: arg,
-    nameFodder(f):: self { nameFodder::: wrapArray(f) },
-    eqFodder(f):: self { eqFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
   },
 
-  Object(fields=[]): NodeBase {
-    __kind__: 'Object',
+  Object(fields=[]): ast.Object {
     fields: fields,
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
-  Field(id, expr): {
-    __kind__: 'ObjectField',
+  Field(id, expr): ast.ObjectField {
     id: if std.type(id) == 'string' then id else null,
     expr1: if std.type(id) == 'object' then id else null,
     expr2: expr,
-    kind: if std.type(id) == 'string' then 1 else 2,
-    Hide: 1,
-    opFodder(f):: self { opFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+    kind: if std.type(id) == 'string' then 'fieldId' else 'fieldExpr',
+    hide: 'inherit',
+    plus():: self { superSugar: true },
+  },
+  HiddenField(id, expr): self.Field(id, expr) {
+    hide: 'hidden',
+  },
+  ForcedField(id, expr): self.Field(id, expr) {
+    hide: 'visible',
   },
-  FieldLocal(id, expr): {
-    __kind__: 'ObjectField',
+  FieldLocal(id, expr): ast.ObjectField {
     id: id,
     expr2: expr,
-    kind: 4,
-    Hide: 2,
-    opFodder(f):: self { opFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+    kind: 'local',
+    hide: 'visible',
   },
-  FieldAssert(cond, message): {
-    __kind__: 'ObjectField',
+  FieldAssert(cond, message): ast.ObjectField {
     expr2: cond,
     expr3: message,
-    kind: 0,
-    Hide: 2,
-    opFodder(f):: self { opFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+    kind: 'assert',
+    hide: 'visible',
   },
-  FieldFunction(id, parameters, body): {
-    __kind__: 'ObjectField',
+  FieldFunction(id, parameters, body): ast.ObjectField {
     id: if std.type(id) == 'string' then id else null,
     expr1: if std.type(id) == 'object' then id else null,
     method: $.Function(parameters, body),
     expr2: body,
-    kind: if std.type(id) == 'string' then 1 else 2,
-    Hide: 1,
-    opFodder(f):: self { opFodder::: wrapArray(f) },
-    commaFodder(f):: self { commaFodder::: wrapArray(f) },
+    kind: if std.type(id) == 'string' then 'fieldId' else 'fieldExpr',
+    hide: 'inherit',
   },
-  ApplyBrace(left, right): NodeBase {
-    __kind__: 'ApplyBrace',
+  HiddenFieldFunction(id, parameters, body): self.FieldFunction(id, parameters, body) {
+    hide: 'hidden',
+  },
+  ForcedFieldFunction(id, parameters, body): self.FieldFunction(id, parameters, body) {
+    hide: 'visible',
+  },
+  ApplyBrace(left, right): ast.ApplyBrace {
     left: left,
     right: right,
   },
 
-  Array(elements=[]): NodeBase {
-    __kind__: 'Array',
+  Array(elements=[]): ast.Array {
     elements: [if elem.__kind__ == 'CommaSeparatedExpr' then elem else $.CommaSeparatedExpr(elem) for elem in elements],
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
 
-  ObjectComp(fields=[], specs=[]): NodeBase {
-    __kind__: 'ObjectComp',
+  ObjectComp(fields=[], specs=[]): ast.ObjectComp {
     fields: fields,
     spec: std.foldl(
       function(acc, curr)
@@ -198,11 +159,8 @@ local NodeBase = {
       specs,
       null
     ),
-    trailingCommaFodder(f):: self { trailingCommaFodder::: wrapArray(f) },
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
-  ArrayComp(body, specs=[]): NodeBase {
-    __kind__: 'ArrayComp',
+  ArrayComp(body, specs=[]): ast.ArrayComp {
     body: body,
     spec: std.foldl(
       function(acc, curr)
@@ -216,124 +174,100 @@ local NodeBase = {
       specs,
       null
     ),
-    trailingCommaFodder(f):: self { trailingCommaFodder::: wrapArray(f) },
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
-  ForSpec(varName, expr): {
-    __kind__: 'ForSpec',
+  ForSpec(varName, expr): ast.ForSpec {
     varName: varName,
     expr: expr,
-    forFodder(f):: self { forFodder::: wrapArray(f) },
-    varFodder(f):: self { varFodder::: wrapArray(f) },
-    inFodder(f):: self { inFodder::: wrapArray(f) },
   },
-  IfSpec(expr): {
-    __kind__: 'IfSpec',
+  IfSpec(expr): ast.IfSpec {
     expr: expr,
-    ifFodder(f):: self { ifFodder::: wrapArray(f) },
   },
 
-  If(cond, branchTrue, branchFalse=null): NodeBase {
-    __kind__: 'Conditional',
+  If(cond, branchTrue, branchFalse=null): ast.Conditional {
     cond: cond,
     branchTrue: branchTrue,
     branchFalse: branchFalse,
-    thenFodder(f):: self { thenFodder::: wrapArray(f) },
-    elseFodder(f):: self { elseFodder::: wrapArray(f) },
   },
 
-  Local(binds, body): NodeBase {
-    __kind__: 'Local',
+  Local(binds, body): ast.Local {
     binds: if std.type(binds) == 'array' then binds else [binds],
     body: body,
   },
   Locals(localBinds, body): std.foldr(function(curr, acc) $.Local(curr, acc), localBinds, body),
-  LocalBind(variable, body): {
-    __kind__: 'LocalBind',
+  LocalBind(variable, body): ast.LocalBind {
     variable: variable,
     body: body,
-    varFodder(f):: self { varFodder::: wrapArray(f) },
-    eqFodder(f):: self { eqFodder::: wrapArray(f) },
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
-  LocalFunctionBind(variable, parameters, body): {
-    __kind__: 'LocalBind',
+  LocalFunctionBind(variable, parameters, body): ast.LocalBind {
     variable: variable,
     body: body,
     fun: $.Function(parameters, body),
-    varFodder(f):: self { varFodder::: wrapArray(f) },
-    eqFodder(f):: self { eqFodder::: wrapArray(f) },
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
 
-  Assert(cond, message, rest): NodeBase {
-    __kind__: 'Assert',
+  Assert(cond, message, rest): ast.Assert {
     cond: cond,
     message: message,
     rest: rest,
-    colonFodder(f):: self { colonFodder::: wrapArray(f) },
-    semicolonFodder(f):: self { semicolonFodder::: wrapArray(f) },
   },
-  Error(expr): NodeBase {
-    __kind__: 'Error',
+  Error(expr): ast.Error {
     expr: expr,
   },
 
-  Parens(inner): NodeBase {
-    __kind__: 'Parens',
+  Parens(inner): ast.Parens {
     inner: inner,
-    closeFodder(f):: self { closeFodder::: wrapArray(f) },
   },
 
-  Import(file): NodeBase {
-    __kind__: 'Import',
+  Import(file): ast.Import {
     file: if std.type(file) == 'string' then $.String(file) else file,
   },
-  ImportStr(file): NodeBase {
-    __kind__: 'ImportStr',
+  ImportStr(file): ast.ImportStr {
     file: if std.type(file) == 'string' then $.String(file) else file,
   },
-  ImportBin(file): NodeBase {
-    __kind__: 'ImportBin',
+  ImportBin(file): ast.ImportBin {
     file: if std.type(file) == 'string' then $.String(file) else file,
   },
 
-  Binary(left, op, right): NodeBase {
-    __kind__: 'Binary',
+  Binary(left, op, right): ast.Binary {
     left: left,
     right: right,
     op: op,
-    opFodder(f):: self { opFodder::: wrapArray(f) },
-  },
-  Mul(left, right): self.Binary(left, 0, right),
-  Div(left, right): self.Binary(left, 1, right),
-  Percent(left, right): self.Binary(left, 2, right),
-  Add(left, right): self.Binary(left, 3, right),
-  Sub(left, right): self.Binary(left, 4, right),
-  LShift(left, right): self.Binary(left, 5, right),
-  RShift(left, right): self.Binary(left, 6, right),
-  Gt(left, right): self.Binary(left, 7, right),
-  Gte(left, right): self.Binary(left, 8, right),
-  Lt(left, right): self.Binary(left, 9, right),
-  Lte(left, right): self.Binary(left, 10, right),
-  In(left, right): self.Binary(left, 11, right),
-  Eq(left, right): self.Binary(left, 12, right),
-  Neq(left, right): self.Binary(left, 13, right),
-  BitAnd(left, right): self.Binary(left, 14, right),
-  BitXor(left, right): self.Binary(left, 15, right),
-  BitOr(left, right): self.Binary(left, 16, right),
-  And(left, right): self.Binary(left, 17, right),
-  Or(left, right): self.Binary(left, 18, right),
-
-  Unary(expr, op): NodeBase {
-    __kind__: 'Unary',
+  },
+  Mul(left, right): self.Binary(left, '*', right),
+  Div(left, right): self.Binary(left, '/', right),
+  Percent(left, right): self.Binary(left, '%', right),
+  Add(left, right): self.Binary(left, '+', right),
+  Sub(left, right): self.Binary(left, '-', right),
+  LShift(left, right): self.Binary(left, '<<', right),
+  RShift(left, right): self.Binary(left, '>>', right),
+  Gt(left, right): self.Binary(left, '>', right),
+  Gte(left, right): self.Binary(left, '>=', right),
+  Lt(left, right): self.Binary(left, '<', right),
+  Lte(left, right): self.Binary(left, '<=', right),
+  In(left, right): self.Binary(left, 'in', right),
+  Eq(left, right): self.Binary(left, '==', right),
+  Neq(left, right): self.Binary(left, '!=', right),
+  BitAnd(left, right): self.Binary(left, '&', right),
+  BitXor(left, right): self.Binary(left, '^', right),
+  BitOr(left, right): self.Binary(left, '|', right),
+  And(left, right): self.Binary(left, '&&', right),
+  Or(left, right): self.Binary(left, '||', right),
+
+  Unary(expr, op): ast.Unary {
     expr: expr,
     op: op,
   },
-  Not(a): self.Unary(a, 0),
-  BitNot(a): self.Unary(a, 1),
-  Plus(a): self.Unary(a, 2),
-  Minus(a): self.Unary(a, 3),
+  Not(a): self.Unary(a, '!'),
+  BitNot(a): self.Unary(a, '~'),
+  Plus(a): self.Unary(a, '+'),
+  Minus(a): self.Unary(a, '-'),
+
+  Capture(name): {
+    __kind__: 'Capture',
+    name: name,
+  },
+
+  fromValue(value, options={}): $.valueToAst(value, options),
+  template(code, holes={}): $.templateJsonnet(code, holes),
 
   Std: {
     // External Variables
@@ -504,17 +438,34 @@ local NodeBase = {
       blanks: blanks,
       indent: 0,
       comment: [],
-      kind: 0,
+      kind: 'lineEnd',
     },
     LineEnd(blanks=0, indent=0, comment=null): {
       blanks: blanks,
       indent: indent,
       comment: if comment != null then [comment] else [],
-      kind: 0,
+      kind: 'lineEnd',
     },
   },
 
-  formatJsonnet(jsonnet): std.native('invoke:jsonnet')('formatJsonnet', [jsonnet]),
-  manifestJsonnet(jsonnet): std.native('invoke:jsonnet')('manifestJsonnet', [jsonnet]),
-  parseJsonnet(jsonnet): std.native('invoke:jsonnet')('parseJsonnet', [jsonnet]),
+  analyzeJsonnet(jsonnet, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('analyzeJsonnet', [jsonnet, options, filename]),
+  desugarJsonnet(jsonnet, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('desugarJsonnet', [jsonnet, options, filename]),
+  diffJsonnet(a, b): std.native('invoke:jsonnet')('diffJsonnet', [a, b]),
+  equalJsonnet(a, b, options={}): std.native('invoke:jsonnet')('equalJsonnet', [a, b, options]),
+  evalJsonnet(code, options={}): std.native('invoke:jsonnet')('evalJsonnet', [code, options]),
+  evaluateJsonnet(jsonnet, options={}): std.native('invoke:jsonnet')('evaluateJsonnet', [jsonnet, options]),
+  formatJsonnet(jsonnet, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('formatJsonnet', [jsonnet, options, filename]),
+  importGraph(rootPath, files): std.native('invoke:jsonnet')('importGraph', [rootPath, files]),
+  importsJsonnet(code, filename='main.jsonnet'): std.native('invoke:jsonnet')('importsJsonnet', [code, filename]),
+  jsonToJsonnet(text): std.native('invoke:jsonnet')('jsonToJsonnet', [text]),
+  lintJsonnet(code, config={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('lintJsonnet', [code, config, filename]),
+  manifestJsonnet(jsonnet, options={}): std.native('invoke:jsonnet')('manifestJsonnet', [jsonnet, options]),
+  parseJsonnet(jsonnet, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('parseJsonnet', [jsonnet, options, filename]),
+  queryJsonnet(jsonnet, selector): std.native('invoke:jsonnet')('queryJsonnet', [jsonnet, selector]),
+  renameJsonnet(code, line, column, newName): std.native('invoke:jsonnet')('renameJsonnet', [code, line, column, newName]),
+  templateJsonnet(code, holes, filename='main.jsonnet'): std.native('invoke:jsonnet')('templateJsonnet', [code, holes, filename]),
+  transformJsonnet(code, rules, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('transformJsonnet', [code, rules, options, filename]),
+  tryParseJsonnet(jsonnet, options={}, filename='main.jsonnet'): std.native('invoke:jsonnet')('tryParseJsonnet', [jsonnet, options, filename]),
+  valueToAst(value, options={}): std.native('invoke:jsonnet')('valueToAst', [value, options]),
+  yamlToJsonnet(text): std.native('invoke:jsonnet')('yamlToJsonnet', [text]),
 }
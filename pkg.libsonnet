@@ -15,6 +15,8 @@ p.pkg({
   Self: p.desc('Self'),
   Dollar: p.desc('Dollar'),
   String: p.desc('String'),
+  TextBlock: p.desc('TextBlock'),
+  VerbatimString: p.desc('VerbatimString'),
   Number: p.desc('Number'),
   Var: p.desc('Var'),
 
@@ -53,8 +55,30 @@ p.pkg({
   Binary: p.desc('Binary'),
   Unary: p.desc('Unary'),
 
+  Capture: p.desc('Capture'),
+
+  fromValue: p.desc('fromValue'),
+  template: p.desc('template'),
+
   Std: p.desc('Std'),
 
+  analyzeJsonnet: p.desc('analyzeJsonnet'),
+  desugarJsonnet: p.desc('desugarJsonnet'),
+  diffJsonnet: p.desc('diffJsonnet'),
+  equalJsonnet: p.desc('equalJsonnet'),
+  evalJsonnet: p.desc('evalJsonnet'),
+  evaluateJsonnet: p.desc('evaluateJsonnet'),
+  importGraph: p.desc('importGraph'),
+  importsJsonnet: p.desc('importsJsonnet'),
+  jsonToJsonnet: p.desc('jsonToJsonnet'),
+  lintJsonnet: p.desc('lintJsonnet'),
   manifestJsonnet: p.desc('manifestJsonnet'),
   parseJsonnet: p.desc('parseJsonnet'),
+  queryJsonnet: p.desc('queryJsonnet'),
+  renameJsonnet: p.desc('renameJsonnet'),
+  templateJsonnet: p.desc('templateJsonnet'),
+  transformJsonnet: p.desc('transformJsonnet'),
+  tryParseJsonnet: p.desc('tryParseJsonnet'),
+  valueToAst: p.desc('valueToAst'),
+  yamlToJsonnet: p.desc('yamlToJsonnet'),
 })